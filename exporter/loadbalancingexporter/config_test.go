@@ -17,6 +17,7 @@ package loadbalancingexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,4 +34,13 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
+
+	dnsCfg := cfg.Exporters["loadbalancing/2"].(*Config)
+	require.NotNil(t, dnsCfg.Resolver.DNS)
+	assert.Equal(t, &DNSResolver{
+		Hostname: "service-1",
+		Port:     "55690",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}, dnsCfg.Resolver.DNS)
 }
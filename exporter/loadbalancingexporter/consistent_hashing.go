@@ -148,6 +148,16 @@ func positionsForEndpoints(endpoints []string, weight int) []ringItem {
 	return items
 }
 
+// hasEndpoint returns whether the given endpoint is part of the ring
+func (h *hashRing) hasEndpoint(endpoint string) bool {
+	for _, item := range h.items {
+		if item.endpoint == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *hashRing) equal(candidate *hashRing) bool {
 	if candidate == nil {
 		return false
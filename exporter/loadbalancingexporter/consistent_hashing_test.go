@@ -229,3 +229,11 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestHasEndpoint(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+
+	assert.True(t, ring.hasEndpoint("endpoint-1"))
+	assert.True(t, ring.hasEndpoint("endpoint-2"))
+	assert.False(t, ring.hasEndpoint("endpoint-3"))
+}
@@ -16,42 +16,304 @@ package loadbalancingexporter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpertrace"
 )
 
 var _ component.TracesExporter = (*exporterImp)(nil)
 
+const (
+	defaultPort = "55680"
+
+	// backendShutdownTimeout bounds the shutdown of the exporters for removed backends, as the
+	// traces for the other backends wait for the ring update to complete.
+	backendShutdownTimeout = 5 * time.Second
+)
+
+var (
+	errNoResolver                = errors.New("no resolvers specified for the exporter")
+	errMultipleResolversProvided = errors.New("only one resolver should be specified")
+	errNoBackends                = errors.New("no backends are available at the moment")
+	errNoTracesInBatch           = errors.New("no traces were found in the batch")
+	errBackendRemoved            = errors.New("the backend was removed from the ring while the trace was being routed, try again")
+)
+
+// componentFactory builds the exporter responsible for sending data to the given endpoint
+type componentFactory func(ctx context.Context, endpoint string) (component.TracesExporter, error)
+
 type exporterImp struct {
 	logger *zap.Logger
 	config Config
+
+	host component.Host
+
+	res  resolver
+	ring *hashRing
+
+	exporters       map[string]component.TracesExporter
+	exporterFactory componentFactory
+
+	updateLock sync.RWMutex
 }
 
 // Crete new exporter
 func newExporter(params component.ExporterCreateParams, cfg configmodels.Exporter) (*exporterImp, error) {
 	oCfg := cfg.(*Config)
 
+	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+		return nil, errMultipleResolversProvided
+	}
+
+	var res resolver
+	if oCfg.Resolver.Static != nil {
+		var err error
+		res, err = newStaticResolver(oCfg.Resolver.Static.Hostnames)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if oCfg.Resolver.DNS != nil {
+		dnsLogger := params.Logger.With(zap.String("resolver", "dns"))
+
+		var err error
+		res, err = newDNSResolver(dnsLogger, oCfg.Resolver.DNS.Hostname, oCfg.Resolver.DNS.Port, oCfg.Resolver.DNS.Interval, oCfg.Resolver.DNS.Timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if res == nil {
+		return nil, errNoResolver
+	}
+
+	otlpFactory := otlpexporter.NewFactory()
+	exporterFactory := func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		expCfg := buildExporterConfig(*oCfg, endpoint)
+		return otlpFactory.CreateTracesExporter(ctx, params, &expCfg)
+	}
+
 	return &exporterImp{
-		logger: params.Logger,
-		config: *oCfg,
+		logger:          params.Logger,
+		config:          *oCfg,
+		res:             res,
+		exporters:       map[string]component.TracesExporter{},
+		exporterFactory: exporterFactory,
 	}, nil
 }
 
+func buildExporterConfig(cfg Config, endpoint string) otlpexporter.Config {
+	oCfg := cfg.Protocol.OTLP
+	oCfg.Endpoint = endpoint
+	return oCfg
+}
+
 func (e *exporterImp) Start(ctx context.Context, host component.Host) error {
-	return nil
+	e.host = host
+	e.res.onChange(e.onBackendChanges)
+	return e.res.start(ctx)
+}
+
+// onBackendChanges rebuilds the ring for the new list of backends, shutting down the exporters
+// for the backends that are gone. Exporters for the new backends are created when they are first needed.
+// As the ring is a consistent hash ring, only the traces that belonged to the backends that were added or
+// removed are assigned to a different backend.
+func (e *exporterImp) onBackendChanges(resolved []string) {
+	endpoints := make([]string, len(resolved))
+	for i, endpoint := range resolved {
+		endpoints[i] = endpointWithPort(endpoint)
+	}
+
+	var newRing *hashRing
+	if len(endpoints) > 0 {
+		newRing = newHashRing(endpoints)
+	}
+
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	if newRing != nil && newRing.equal(e.ring) {
+		return
+	}
+	e.ring = newRing
+
+	ctx, cancel := context.WithTimeout(context.Background(), backendShutdownTimeout)
+	defer cancel()
+	e.removeExtraExporters(ctx, endpoints)
 }
 
-func (e *exporterImp) Shutdown(context.Context) error {
-	return nil
+// removeExtraExporters shuts down and removes the exporters for backends that aren't part of the given list.
+// The caller is expected to hold the update lock.
+func (e *exporterImp) removeExtraExporters(ctx context.Context, endpoints []string) {
+	for existing, exp := range e.exporters {
+		if !endpointFound(existing, endpoints) {
+			if err := exp.Shutdown(ctx); err != nil {
+				e.logger.Warn("failed to shutdown the exporter for a removed backend", zap.String("endpoint", existing), zap.Error(err))
+			}
+			delete(e.exporters, existing)
+		}
+	}
+}
+
+func endpointFound(endpoint string, endpoints []string) bool {
+	for _, candidate := range endpoints {
+		if candidate == endpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+func endpointWithPort(endpoint string) string {
+	if !strings.Contains(endpoint, ":") {
+		endpoint = fmt.Sprintf("%s:%s", endpoint, defaultPort)
+	}
+	return endpoint
+}
+
+func (e *exporterImp) Shutdown(ctx context.Context) error {
+	var errs []error
+	if err := e.res.shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+	for endpoint, exp := range e.exporters {
+		if err := exp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		delete(e.exporters, endpoint)
+	}
+
+	return componenterror.CombineErrors(errs)
 }
 
 func (e *exporterImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
-	return nil
+	var errs []error
+	batches := batchpertrace.Split(td)
+	for _, batch := range batches {
+		if err := e.consumeTrace(ctx, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+func (e *exporterImp) consumeTrace(ctx context.Context, td pdata.Traces) error {
+	traceID, err := traceIDFromTraces(td)
+	if err != nil {
+		return err
+	}
+
+	e.updateLock.RLock()
+	ring := e.ring
+	e.updateLock.RUnlock()
+
+	if ring == nil {
+		return errNoBackends
+	}
+
+	endpoint := ring.endpointFor(traceID)
+	exp, err := e.exporterFor(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = exp.ConsumeTraces(ctx, td)
+	duration := time.Since(start)
+
+	ctx, _ = tag.New(ctx, tag.Upsert(endpointTagKey, endpoint))
+	if err == nil {
+		sCtx, _ := tag.New(ctx, successTrueMutator)
+		stats.Record(sCtx, mBackendOutcome.M(1))
+		stats.Record(ctx, mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		fCtx, _ := tag.New(ctx, successFalseMutator)
+		stats.Record(fCtx, mBackendOutcome.M(1))
+	}
+
+	return err
+}
+
+// exporterFor returns the exporter for the given endpoint, creating and starting it if it doesn't exist yet.
+func (e *exporterImp) exporterFor(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+	e.updateLock.RLock()
+	exp, found := e.exporters[endpoint]
+	e.updateLock.RUnlock()
+	if found {
+		return exp, nil
+	}
+
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	// the exporter might have been created while we were waiting for the lock
+	if exp, found = e.exporters[endpoint]; found {
+		return exp, nil
+	}
+
+	// the ring might have changed since the endpoint was picked, in which case the exporter would
+	// never be shut down, as only the exporters for the backends in the ring are tracked
+	if e.ring == nil || !e.ring.hasEndpoint(endpoint) {
+		return nil, errBackendRemoved
+	}
+
+	exp, err := e.exporterFactory(ctx, endpoint)
+	if err != nil {
+		e.logger.Error("failed to create new trace exporter for endpoint", zap.String("endpoint", endpoint), zap.Error(err))
+		return nil, err
+	}
+
+	if err = exp.Start(ctx, e.host); err != nil {
+		e.logger.Error("failed to start new trace exporter for endpoint", zap.String("endpoint", endpoint), zap.Error(err))
+		// release whatever the exporter acquired before failing, it's never going to be used
+		if shutdownErr := exp.Shutdown(ctx); shutdownErr != nil {
+			e.logger.Warn("failed to shut down the trace exporter that failed to start", zap.String("endpoint", endpoint), zap.Error(shutdownErr))
+		}
+		return nil, err
+	}
+
+	e.exporters[endpoint] = exp
+	return exp, nil
 }
 
 func (e *exporterImp) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
+
+// traceIDFromTraces returns the trace ID for the given batch, which is expected to contain spans for a single trace.
+func traceIDFromTraces(td pdata.Traces) (pdata.TraceID, error) {
+	rs := td.ResourceSpans()
+	if rs.Len() == 0 {
+		return pdata.InvalidTraceID(), errNoTracesInBatch
+	}
+
+	ils := rs.At(0).InstrumentationLibrarySpans()
+	if ils.Len() == 0 {
+		return pdata.InvalidTraceID(), errNoTracesInBatch
+	}
+
+	spans := ils.At(0).Spans()
+	if spans.Len() == 0 {
+		return pdata.InvalidTraceID(), errNoTracesInBatch
+	}
+
+	return spans.At(0).TraceID(), nil
+}
@@ -15,16 +15,22 @@
 package loadbalancingexporter
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
 
 func TestNewExporter(t *testing.T) {
 	// prepare
-	config := &Config{}
+	config := simpleConfig()
 	params := component.ExporterCreateParams{
 		Logger: zap.NewNop(),
 	}
@@ -36,3 +42,368 @@ func TestNewExporter(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, p)
 }
+
+func TestNewExporterWithDNSResolver(t *testing.T) {
+	// prepare
+	config := &Config{
+		Resolver: ResolverSettings{
+			DNS: &DNSResolver{Hostname: "service-1"},
+		},
+	}
+	params := component.ExporterCreateParams{
+		Logger: zap.NewNop(),
+	}
+
+	// test
+	p, err := newExporter(params, config)
+
+	// verify
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &dnsResolver{}, p.res)
+}
+
+func TestNewExporterInvalidResolvers(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		resolver ResolverSettings
+		expected error
+	}{
+		{
+			"no resolver",
+			ResolverSettings{},
+			errNoResolver,
+		},
+		{
+			"multiple resolvers",
+			ResolverSettings{
+				Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+				DNS:    &DNSResolver{Hostname: "service-1"},
+			},
+			errMultipleResolversProvided,
+		},
+		{
+			"DNS resolver without hostname",
+			ResolverSettings{
+				DNS: &DNSResolver{},
+			},
+			errNoHostname,
+		},
+		{
+			"static resolver without endpoints",
+			ResolverSettings{
+				Static: &StaticResolver{},
+			},
+			errNoEndpoints,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			config := &Config{Resolver: tt.resolver}
+			params := component.ExporterCreateParams{
+				Logger: zap.NewNop(),
+			}
+
+			// test
+			p, err := newExporter(params, config)
+
+			// verify
+			assert.Equal(t, tt.expected, err)
+			assert.Nil(t, p)
+		})
+	}
+}
+
+func TestConsumeTraces(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	sink := newMockTracesExporter()
+	p.exporterFactory = func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		return sink, nil
+	}
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer p.Shutdown(context.Background())
+
+	// test
+	err = p.ConsumeTraces(context.Background(), simpleTraces(2))
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, sink.received(), 2)
+	assert.True(t, sink.started)
+}
+
+func TestConsumeTracesBeforeStart(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	err = p.ConsumeTraces(context.Background(), simpleTraces(1))
+
+	// verify
+	assert.Equal(t, errNoBackends, err)
+}
+
+func TestConsumeTracesExporterFailsToBuild(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	p.exporterFactory = func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		return nil, expectedErr
+	}
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer p.Shutdown(context.Background())
+
+	// test
+	err = p.ConsumeTraces(context.Background(), simpleTraces(1))
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestConsumeTracesExporterFailsToStart(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	sink := newMockTracesExporter()
+	sink.startErr = expectedErr
+	p.exporterFactory = func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		return sink, nil
+	}
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer p.Shutdown(context.Background())
+
+	// test
+	err = p.ConsumeTraces(context.Background(), simpleTraces(1))
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+	assert.True(t, sink.shutdown)
+	assert.Empty(t, p.exporters)
+}
+
+func TestConsumeTracesRoutesToSameBackend(t *testing.T) {
+	// prepare
+	config := simpleConfig()
+	config.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, config)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	sinks := map[string]*mockTracesExporter{}
+	p.exporterFactory = func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		sink := newMockTracesExporter()
+		sinks[endpoint] = sink
+		return sink, nil
+	}
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer p.Shutdown(context.Background())
+
+	// test
+	for i := 0; i < 5; i++ {
+		require.NoError(t, p.ConsumeTraces(context.Background(), simpleTraces(1)))
+	}
+
+	// verify
+	require.Len(t, sinks, 1)
+	for _, sink := range sinks {
+		assert.Len(t, sink.received(), 5)
+	}
+}
+
+func TestRemoveExtraExporters(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	sink := newMockTracesExporter()
+	p.exporters["endpoint-1:55680"] = newMockTracesExporter()
+	p.exporters["endpoint-2:55680"] = sink
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1"})
+
+	// verify
+	assert.Len(t, p.exporters, 1)
+	assert.NotContains(t, p.exporters, "endpoint-2:55680")
+	assert.True(t, sink.shutdown)
+}
+
+func TestNoBackendsAfterChange(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	sink := newMockTracesExporter()
+	p.exporters["endpoint-1:55680"] = sink
+	p.onBackendChanges([]string{"endpoint-1"})
+	require.NotNil(t, p.ring)
+
+	// test
+	p.onBackendChanges([]string{})
+	err = p.ConsumeTraces(context.Background(), simpleTraces(1))
+
+	// verify
+	assert.Equal(t, errNoBackends, err)
+	assert.Empty(t, p.exporters)
+	assert.True(t, sink.shutdown)
+}
+
+func TestExporterForRemovedBackend(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	created := false
+	p.exporterFactory = func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		created = true
+		return newMockTracesExporter(), nil
+	}
+	p.onBackendChanges([]string{"endpoint-1"})
+
+	// test
+	// the endpoint was picked from a previous version of the ring
+	exp, err := p.exporterFor(context.Background(), "endpoint-2:55680")
+
+	// verify
+	assert.Equal(t, errBackendRemoved, err)
+	assert.Nil(t, exp)
+	assert.False(t, created)
+	assert.Empty(t, p.exporters)
+}
+
+func TestShutdownExporters(t *testing.T) {
+	// prepare
+	p, err := newExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	sink := newMockTracesExporter()
+	p.exporters["endpoint-1:55680"] = sink
+
+	// test
+	err = p.Shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Empty(t, p.exporters)
+	assert.True(t, sink.shutdown)
+}
+
+func TestBuildExporterConfig(t *testing.T) {
+	// prepare
+	config := createDefaultConfig().(*Config)
+	config.Protocol.OTLP.Endpoint = "should-be-overridden:55680"
+
+	// test
+	cfg := buildExporterConfig(*config, "endpoint-1:55680")
+
+	// verify
+	assert.Equal(t, "endpoint-1:55680", cfg.Endpoint)
+	assert.Equal(t, config.Protocol.OTLP.QueueSettings, cfg.QueueSettings)
+}
+
+func TestEndpointWithPort(t *testing.T) {
+	for _, tt := range []struct {
+		input, expected string
+	}{
+		{
+			"endpoint-1",
+			"endpoint-1:55680",
+		},
+		{
+			"endpoint-1:55690",
+			"endpoint-1:55690",
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, endpointWithPort(tt.input))
+		})
+	}
+}
+
+func TestTraceIDFromEmptyBatch(t *testing.T) {
+	// prepare
+	td := pdata.NewTraces()
+
+	// test
+	_, err := traceIDFromTraces(td)
+
+	// verify
+	assert.Equal(t, errNoTracesInBatch, err)
+}
+
+func simpleConfig() *Config {
+	return &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+	}
+}
+
+// simpleTraces builds a batch with the given number of traces, each with a single span
+func simpleTraces(numTraces int) pdata.Traces {
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().Resize(1)
+	rs := traces.ResourceSpans().At(0)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.Spans().Resize(numTraces)
+	for i := 0; i < numTraces; i++ {
+		ils.Spans().At(i).SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, byte(i)}))
+	}
+	return traces
+}
+
+type mockTracesExporter struct {
+	sync.Mutex
+	traces   []pdata.Traces
+	started  bool
+	shutdown bool
+	startErr error
+}
+
+func newMockTracesExporter() *mockTracesExporter {
+	return &mockTracesExporter{}
+}
+
+func (m *mockTracesExporter) Start(context.Context, component.Host) error {
+	m.started = true
+	return m.startErr
+}
+
+func (m *mockTracesExporter) Shutdown(context.Context) error {
+	m.shutdown = true
+	return nil
+}
+
+func (m *mockTracesExporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	m.Lock()
+	defer m.Unlock()
+	m.traces = append(m.traces, td)
+	return nil
+}
+
+func (m *mockTracesExporter) received() []pdata.Traces {
+	m.Lock()
+	defer m.Unlock()
+	return m.traces
+}
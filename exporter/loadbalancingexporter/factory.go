@@ -16,10 +16,14 @@ package loadbalancingexporter
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.uber.org/zap"
 )
 
 const (
@@ -27,6 +31,8 @@ const (
 	typeStr = "loadbalancing"
 )
 
+var registerViewsOnce sync.Once
+
 // NewFactory creates a factory for the exporter.
 func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
@@ -37,14 +43,27 @@ func NewFactory() component.ExporterFactory {
 }
 
 func createDefaultConfig() configmodels.Exporter {
+	otlpFactory := otlpexporter.NewFactory()
+	otlpDefaultCfg := otlpFactory.CreateDefaultConfig().(*otlpexporter.Config)
+
 	return &Config{
 		ExporterSettings: configmodels.ExporterSettings{
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		Protocol: Protocol{
+			OTLP: *otlpDefaultCfg,
+		},
 	}
 }
 
 func createTraceExporter(_ context.Context, params component.ExporterCreateParams, cfg configmodels.Exporter) (component.TracesExporter, error) {
+	// register the views for self-observability, once for all the instances of the exporter
+	registerViewsOnce.Do(func() {
+		if err := view.Register(MetricViews()...); err != nil {
+			params.Logger.Warn("failed to register the metric views", zap.Error(err))
+		}
+	})
+
 	return newExporter(params, cfg)
 }
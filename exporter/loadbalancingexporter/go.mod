@@ -3,7 +3,11 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadba
 go 1.14
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpertrace v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.6.1
+	go.opencensus.io v0.22.4
 	go.opentelemetry.io/collector v0.13.1-0.20201101004512-f4e4382d0e0e
 	go.uber.org/zap v1.16.0
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpertrace => ../../pkg/batchpertrace
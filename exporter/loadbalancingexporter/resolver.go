@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import "context"
+
+// resolver provides the list of backends that the load balancer should distribute the traces to.
+type resolver interface {
+	// start initializes the resolver, performing the initial resolution
+	start(context.Context) error
+
+	// shutdown stops the resolver, releasing any resources it might be holding
+	shutdown(context.Context) error
+
+	// resolve returns the current list of endpoints
+	resolve(context.Context) ([]string, error)
+
+	// onChange registers a function to be called whenever the list of endpoints changes
+	onChange(func([]string))
+}
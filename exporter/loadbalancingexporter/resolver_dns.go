@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+var _ resolver = (*dnsResolver)(nil)
+
+const (
+	defaultResInterval = 5 * time.Second
+	defaultResTimeout  = time.Second
+)
+
+var (
+	errNoHostname = errors.New("no hostname specified to resolve the backends")
+
+	dnsResolverMutator = tag.Upsert(resolverTagKey, "dns")
+)
+
+type dnsResolver struct {
+	logger *zap.Logger
+
+	hostname    string
+	port        string
+	resolver    netResolver
+	resInterval time.Duration
+	resTimeout  time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	stopOnce           sync.Once
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+// netResolver is the subset of the net.Resolver used by the DNS resolver, allowing it to be replaced in tests
+type netResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func newDNSResolver(logger *zap.Logger, hostname string, port string, interval time.Duration, timeout time.Duration) (*dnsResolver, error) {
+	if len(hostname) == 0 {
+		return nil, errNoHostname
+	}
+	if len(port) == 0 {
+		port = defaultPort
+	}
+	if interval == 0 {
+		interval = defaultResInterval
+	}
+	if timeout == 0 {
+		timeout = defaultResTimeout
+	}
+
+	return &dnsResolver{
+		logger:      logger,
+		hostname:    hostname,
+		port:        port,
+		resolver:    &net.Resolver{},
+		resInterval: interval,
+		resTimeout:  timeout,
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+func (r *dnsResolver) start(ctx context.Context) error {
+	resCtx, cancel := context.WithTimeout(ctx, r.resTimeout)
+	defer cancel()
+	if _, err := r.resolve(resCtx); err != nil {
+		// the initial resolution isn't fatal: the backends might become available later
+		r.logger.Warn("failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+	}
+
+	r.shutdownWg.Add(1)
+	go r.periodicallyResolve()
+
+	r.logger.Debug("DNS resolver started",
+		zap.String("hostname", r.hostname), zap.String("port", r.port),
+		zap.Duration("interval", r.resInterval), zap.Duration("timeout", r.resTimeout))
+	return nil
+}
+
+func (r *dnsResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *dnsResolver) periodicallyResolve() {
+	defer r.shutdownWg.Done()
+
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
+			if _, err := r.resolve(ctx); err != nil {
+				r.logger.Warn("failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+			}
+			cancel()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *dnsResolver) resolve(ctx context.Context) ([]string, error) {
+	addrs, err := r.resolver.LookupIPAddr(ctx, r.hostname)
+	if err != nil {
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{dnsResolverMutator, successFalseMutator}, mNumResolutions.M(1))
+		return nil, err
+	}
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{dnsResolverMutator, successTrueMutator}, mNumResolutions.M(1))
+
+	backends := make([]string, len(addrs))
+	for i, addr := range addrs {
+		backends[i] = net.JoinHostPort(addr.String(), r.port)
+	}
+
+	// keep the list stable, so that we can compare it with the previous one
+	sort.Strings(backends)
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+	r.endpoints = backends
+	r.updateLock.Unlock()
+
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{dnsResolverMutator}, mNumBackends.M(int64(len(backends))), mBackendUpdates.M(1))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *dnsResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+func equalStringSlice(source, candidate []string) bool {
+	if len(source) != len(candidate) {
+		return false
+	}
+	for i := range source {
+		if source[i] != candidate[i] {
+			return false
+		}
+	}
+
+	return true
+}
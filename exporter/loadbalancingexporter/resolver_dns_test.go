@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInitialDNSResolution(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return []net.IPAddr{
+				{IP: net.IPv4(127, 0, 0, 1)},
+				{IP: net.IPv4(127, 0, 0, 2)},
+				{IP: net.IPv6loopback},
+			}, nil
+		},
+	}
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Len(t, resolved, 3)
+	for i, value := range []string{"127.0.0.1:55680", "127.0.0.2:55680", "[::1]:55680"} {
+		assert.Equal(t, value, resolved[i])
+	}
+}
+
+func TestInitialDNSResolutionWithPort(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "55690", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return []net.IPAddr{
+				{IP: net.IPv4(127, 0, 0, 1)},
+			}, nil
+		},
+	}
+
+	// test
+	resolved, err := res.resolve(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:55690"}, resolved)
+}
+
+func TestErrNoHostname(t *testing.T) {
+	// test
+	res, err := newDNSResolver(zap.NewNop(), "", "", 5*time.Second, 1*time.Second)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoHostname, err)
+}
+
+func TestCantResolve(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return nil, expectedErr
+		},
+	}
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+	_, err = res.resolve(context.Background())
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestInitialDNSResolutionHonorsTimeout(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(ctx context.Context, _ string) ([]net.IPAddr, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	// test
+	start := time.Now()
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func TestOnChangeOnlyWhenListChanges(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	resolve := []net.IPAddr{
+		{IP: net.IPv4(127, 0, 0, 1)},
+	}
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return resolve, nil
+		},
+	}
+
+	counter := 0
+	res.onChange(func(endpoints []string) {
+		counter++
+	})
+
+	// test
+	_, err = res.resolve(context.Background())
+	require.NoError(t, err)
+	_, err = res.resolve(context.Background()) // same list, no change
+	require.NoError(t, err)
+
+	resolve = append(resolve, net.IPAddr{IP: net.IPv4(127, 0, 0, 2)})
+	_, err = res.resolve(context.Background())
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, 2, counter)
+}
+
+func TestPeriodicallyResolve(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 10*time.Millisecond, 1*time.Second)
+	require.NoError(t, err)
+
+	counter := 0
+	resolve := [][]net.IPAddr{
+		{
+			{IP: net.IPv4(127, 0, 0, 1)},
+		},
+		{
+			{IP: net.IPv4(127, 0, 0, 1)},
+			{IP: net.IPv4(127, 0, 0, 2)},
+		},
+		{
+			{IP: net.IPv4(127, 0, 0, 1)},
+			{IP: net.IPv4(127, 0, 0, 2)},
+			{IP: net.IPv4(127, 0, 0, 3)},
+		},
+	}
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			defer func() {
+				counter++
+			}()
+			// for second call, return the second result
+			if counter == 2 {
+				return resolve[1], nil
+			}
+			// for subsequent calls, return the last result, so that the list doesn't change anymore
+			if counter >= 3 {
+				return resolve[2], nil
+			}
+			return resolve[0], nil
+		},
+	}
+
+	wg := sync.WaitGroup{}
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+		wg.Done()
+	})
+
+	// test
+	wg.Add(3)
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// wait for three resolutions: from the start, and two periodic resolutions
+	wg.Wait()
+
+	// verify
+	assert.Len(t, resolved, 3)
+	for i, value := range []string{"127.0.0.1:55680", "127.0.0.2:55680", "127.0.0.3:55680"} {
+		assert.Equal(t, value, resolved[i])
+	}
+}
+
+func TestShutdownTwice(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return []net.IPAddr{{IP: net.IPv4(127, 0, 0, 1)}}, nil
+		},
+	}
+	require.NoError(t, res.start(context.Background()))
+
+	// test and verify
+	assert.NoError(t, res.shutdown(context.Background()))
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestShutdownClearsCallbacks(t *testing.T) {
+	// prepare
+	res, err := newDNSResolver(zap.NewNop(), "service-1", "", 5*time.Second, 1*time.Second)
+	require.NoError(t, err)
+
+	res.resolver = &mockDNSResolver{
+		onLookupIPAddr: func(context.Context, string) ([]net.IPAddr, error) {
+			return nil, nil
+		},
+	}
+	res.onChange(func(s []string) {})
+	require.NoError(t, res.start(context.Background()))
+
+	// test
+	err = res.shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, res.onChangeCallbacks, 0)
+}
+
+var _ netResolver = (*mockDNSResolver)(nil)
+
+type mockDNSResolver struct {
+	onLookupIPAddr func(context.Context, string) ([]net.IPAddr, error)
+}
+
+func (m *mockDNSResolver) LookupIPAddr(ctx context.Context, hostname string) ([]net.IPAddr, error) {
+	if m.onLookupIPAddr != nil {
+		return m.onLookupIPAddr(ctx, hostname)
+	}
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+var _ resolver = (*staticResolver)(nil)
+
+var (
+	errNoEndpoints = errors.New("no endpoints specified for the static resolver")
+
+	staticResolverMutator = tag.Upsert(resolverTagKey, "static")
+)
+
+type staticResolver struct {
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+}
+
+func newStaticResolver(endpoints []string) (*staticResolver, error) {
+	if len(endpoints) == 0 {
+		return nil, errNoEndpoints
+	}
+
+	// make sure we have a stable list of endpoints
+	sorted := make([]string, len(endpoints))
+	copy(sorted, endpoints)
+	sort.Strings(sorted)
+
+	return &staticResolver{
+		endpoints: sorted,
+	}, nil
+}
+
+func (r *staticResolver) start(ctx context.Context) error {
+	endpoints, err := r.resolve(ctx) // right now, this can't fail
+	if err != nil {
+		return err
+	}
+
+	// the list of endpoints never changes, so this is the only time we notify the callbacks
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{staticResolverMutator, successTrueMutator}, mNumResolutions.M(1))
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{staticResolverMutator}, mNumBackends.M(int64(len(endpoints))), mBackendUpdates.M(1))
+	for _, callback := range r.onChangeCallbacks {
+		callback(endpoints)
+	}
+	return nil
+}
+
+func (r *staticResolver) shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (r *staticResolver) resolve(ctx context.Context) ([]string, error) {
+	return r.endpoints, nil
+}
+
+func (r *staticResolver) onChange(f func([]string)) {
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for docker observer.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the URL of the docker daemon, either a unix socket or a TCP address.
+	// Default is unix:///var/run/docker.sock
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Timeout is the maximum amount of time to wait for the docker API responses.
+	// Default is 5s.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// UseHostBindings makes the observer use the address and port of the host the container
+	// ports are bound to as the endpoint target, instead of the container's address and port.
+	// Container ports not bound to the host are then ignored. Default is false.
+	UseHostBindings bool `mapstructure:"use_host_bindings"`
+}
@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	dfilters "github.com/docker/docker/api/types/filters"
+	docker "github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	dockerAPIVersion = "v1.22"
+	userAgent        = "OpenTelemetry-Collector Docker Observer/v0.0.1"
+)
+
+type dockerObserver struct {
+	logger *zap.Logger
+	config *Config
+	client *docker.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var _ component.ServiceExtension = (*dockerObserver)(nil)
+var _ observer.Observable = (*dockerObserver)(nil)
+
+// newObserver creates a new docker observer extension.
+func newObserver(logger *zap.Logger, config *Config) (component.ServiceExtension, error) {
+	client, err := docker.NewClientWithOpts(
+		docker.WithHost(config.Endpoint),
+		docker.WithVersion(dockerAPIVersion),
+		docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create docker client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &dockerObserver{
+		logger: logger,
+		config: config,
+		client: client,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+func (d *dockerObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (d *dockerObserver) Shutdown(context.Context) error {
+	d.cancel()
+	return nil
+}
+
+// ListAndWatch notifies watcher with the current state and sends subsequent state changes.
+func (d *dockerObserver) ListAndWatch(listener observer.Notify) {
+	w := &containerWatcher{
+		observer:  d,
+		listener:  listener,
+		endpoints: map[string]map[observer.EndpointID]observer.Endpoint{},
+	}
+	go w.run(d.ctx)
+}
+
+// containerWatcher keeps track of the endpoints of each container and notifies its
+// listener of any change.
+type containerWatcher struct {
+	observer *dockerObserver
+	listener observer.Notify
+	// endpoints holds the current endpoints keyed by container id.
+	endpoints map[string]map[observer.EndpointID]observer.Endpoint
+}
+
+func (w *containerWatcher) run(ctx context.Context) {
+	// Events are requested from the time before the initial listing so that changes
+	// happening while containers are being inspected are not lost.
+	lastTime := time.Now()
+	w.syncContainers(ctx)
+
+	filters := dfilters.NewArgs([]dfilters.KeyValuePair{
+		{Key: "type", Value: "container"},
+		{Key: "event", Value: "destroy"},
+		{Key: "event", Value: "die"},
+		{Key: "event", Value: "pause"},
+		{Key: "event", Value: "rename"},
+		{Key: "event", Value: "stop"},
+		{Key: "event", Value: "start"},
+		{Key: "event", Value: "unpause"},
+		{Key: "event", Value: "update"},
+	}...)
+
+EVENT_LOOP:
+	for {
+		options := dtypes.EventsOptions{
+			Filters: filters,
+			Since:   lastTime.Format(time.RFC3339Nano),
+		}
+		eventCh, errCh := w.observer.client.Events(ctx, options)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-eventCh:
+				switch event.Action {
+				case "destroy", "die", "pause", "stop":
+					w.observer.logger.Debug(
+						"Docker container stopped:",
+						zap.String("id", event.ID),
+						zap.String("action", event.Action),
+					)
+					w.updateContainer(event.ID, nil)
+				default:
+					w.observer.logger.Debug(
+						"Docker container update:",
+						zap.String("id", event.ID),
+						zap.String("action", event.Action),
+					)
+					w.inspectAndUpdateContainer(ctx, event.ID)
+				}
+
+				if event.TimeNano > lastTime.UnixNano() {
+					lastTime = time.Unix(0, event.TimeNano)
+				}
+
+			case err := <-errCh:
+				// We are only interested when the context hasn't been canceled since requests made
+				// with a closed context are guaranteed to fail.
+				if ctx.Err() == nil {
+					w.observer.logger.Error("Error watching docker container events", zap.Error(err))
+					// Either decoding or connection error has occurred, so we should resume the event loop after
+					// waiting a moment.  In cases of extended daemon unavailability this will retry until
+					// collector teardown or background context is closed.
+					select {
+					case <-time.After(3 * time.Second):
+						continue EVENT_LOOP
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// syncContainers lists the running containers and notifies the listener of their endpoints.
+func (w *containerWatcher) syncContainers(ctx context.Context) {
+	filters := dfilters.NewArgs()
+	filters.Add("status", "running")
+	options := dtypes.ContainerListOptions{
+		Filters: filters,
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, w.observer.config.Timeout)
+	containers, err := w.observer.client.ContainerList(listCtx, options)
+	cancel()
+	if err != nil {
+		w.observer.logger.Error("Could not list docker containers", zap.Error(err))
+		return
+	}
+
+	for _, c := range containers {
+		w.inspectAndUpdateContainer(ctx, c.ID)
+	}
+}
+
+func (w *containerWatcher) inspectAndUpdateContainer(ctx context.Context, cid string) {
+	inspectCtx, cancel := context.WithTimeout(ctx, w.observer.config.Timeout)
+	container, err := w.observer.client.ContainerInspect(inspectCtx, cid)
+	cancel()
+	if err != nil {
+		if docker.IsErrNotFound(err) {
+			w.updateContainer(cid, nil)
+			return
+		}
+		w.observer.logger.Error(
+			"Could not inspect docker container",
+			zap.String("id", cid),
+			zap.Error(err),
+		)
+		return
+	}
+
+	w.updateContainer(cid, w.observer.endpointsForContainer(&container))
+}
+
+// updateContainer replaces the endpoints of the given container and notifies
+// the listener of the endpoints that were added, removed or changed.
+func (w *containerWatcher) updateContainer(cid string, endpoints []observer.Endpoint) {
+	existing := w.endpoints[cid]
+	current := make(map[observer.EndpointID]observer.Endpoint, len(endpoints))
+
+	var added, removed, changed []observer.Endpoint
+	for _, e := range endpoints {
+		current[e.ID] = e
+		old, ok := existing[e.ID]
+		switch {
+		case !ok:
+			added = append(added, e)
+		case !reflect.DeepEqual(old, e):
+			changed = append(changed, e)
+		}
+	}
+	for id, e := range existing {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, e)
+		}
+	}
+
+	if len(current) == 0 {
+		delete(w.endpoints, cid)
+	} else {
+		w.endpoints[cid] = current
+	}
+
+	if len(removed) > 0 {
+		w.listener.OnRemove(removed)
+	}
+	if len(added) > 0 {
+		w.listener.OnAdd(added)
+	}
+	if len(changed) > 0 {
+		w.listener.OnChange(changed)
+	}
+}
+
+// endpointsForContainer returns an endpoint for each port of the container, or nil
+// if the container isn't running.
+func (d *dockerObserver) endpointsForContainer(c *dtypes.ContainerJSON) []observer.Endpoint {
+	if c.ContainerJSONBase == nil || c.State == nil || !c.State.Running || c.State.Paused {
+		return nil
+	}
+
+	base := observer.Container{
+		Name:        strings.TrimPrefix(c.Name, "/"),
+		ContainerID: c.ID,
+		Command:     strings.TrimSpace(strings.Join(append([]string{c.Path}, c.Args...), " ")),
+	}
+	if c.Config != nil {
+		base.Image = c.Config.Image
+		base.Labels = c.Config.Labels
+	}
+
+	var bindings nat.PortMap
+	if c.NetworkSettings != nil {
+		bindings = c.NetworkSettings.Ports
+	}
+
+	var endpoints []observer.Endpoint
+	if d.config.UseHostBindings {
+		for _, port := range sortedBoundPorts(bindings) {
+			for _, binding := range bindings[port] {
+				hostPort, err := nat.ParsePort(binding.HostPort)
+				if err != nil || hostPort == 0 {
+					continue
+				}
+				hostIP := binding.HostIP
+				if hostIP == "" || hostIP == "0.0.0.0" {
+					hostIP = "127.0.0.1"
+				}
+
+				details := base
+				details.Port = uint16(port.Int())
+				details.HostPort = uint16(hostPort)
+				details.Host = hostIP
+				details.Transport = portTransport(port)
+				target := net.JoinHostPort(hostIP, strconv.Itoa(hostPort))
+				endpoints = append(endpoints, observer.Endpoint{
+					ID:      d.endpointID(c.ID, port, target),
+					Target:  target,
+					Details: details,
+				})
+			}
+		}
+		return endpoints
+	}
+
+	ip := containerIP(c)
+	if ip == "" || c.Config == nil {
+		return nil
+	}
+	for _, port := range sortedExposedPorts(c.Config.ExposedPorts) {
+		details := base
+		details.Port = uint16(port.Int())
+		details.Host = ip
+		details.Transport = portTransport(port)
+		if b := bindings[port]; len(b) > 0 {
+			if hostPort, err := nat.ParsePort(b[0].HostPort); err == nil {
+				details.HostPort = uint16(hostPort)
+			}
+		}
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:      d.endpointID(c.ID, port, ""),
+			Target:  net.JoinHostPort(ip, port.Port()),
+			Details: details,
+		})
+	}
+	return endpoints
+}
+
+func (d *dockerObserver) endpointID(cid string, port nat.Port, hostTarget string) observer.EndpointID {
+	id := fmt.Sprintf("(%s)%s:%d/%s", d.config.Name(), cid, port.Int(), port.Proto())
+	if hostTarget != "" {
+		id += "-" + hostTarget
+	}
+	return observer.EndpointID(id)
+}
+
+// containerIP returns the address of the container in the default bridge network,
+// falling back to the first network the container is attached to.
+func containerIP(c *dtypes.ContainerJSON) string {
+	if c.NetworkSettings == nil {
+		return ""
+	}
+	if c.NetworkSettings.IPAddress != "" {
+		return c.NetworkSettings.IPAddress
+	}
+
+	names := make([]string, 0, len(c.NetworkSettings.Networks))
+	for name := range c.NetworkSettings.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if n := c.NetworkSettings.Networks[name]; n != nil && n.IPAddress != "" {
+			return n.IPAddress
+		}
+	}
+	return ""
+}
+
+func portTransport(port nat.Port) observer.Transport {
+	switch port.Proto() {
+	case "tcp":
+		return observer.ProtocolTCP
+	case "udp":
+		return observer.ProtocolUDP
+	default:
+		return observer.ProtocolUnknown
+	}
+}
+
+// sortedBoundPorts returns the ports of the given bindings in a stable order.
+func sortedBoundPorts(bindings nat.PortMap) []nat.Port {
+	ports := make([]nat.Port, 0, len(bindings))
+	for port := range bindings {
+		ports = append(ports, port)
+	}
+	return sortPorts(ports)
+}
+
+// sortedExposedPorts returns the given exposed ports in a stable order.
+func sortedExposedPorts(exposed nat.PortSet) []nat.Port {
+	ports := make([]nat.Port, 0, len(exposed))
+	for port := range exposed {
+		ports = append(ports, port)
+	}
+	return sortPorts(ports)
+}
+
+func sortPorts(ports []nat.Port) []nat.Port {
+	nat.Sort(ports, func(ip, jp nat.Port) bool {
+		return ip.Int() < jp.Int() || (ip.Int() == jp.Int() && ip.Proto() < jp.Proto())
+	})
+	return ports
+}
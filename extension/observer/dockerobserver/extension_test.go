@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const containerID = "3f4b2e8a9c1d"
+
+type mockNotify struct {
+	sync.Mutex
+	added   []observer.Endpoint
+	removed []observer.Endpoint
+	changed []observer.Endpoint
+}
+
+func (m *mockNotify) OnAdd(added []observer.Endpoint) {
+	m.Lock()
+	defer m.Unlock()
+	m.added = append(m.added, added...)
+}
+
+func (m *mockNotify) OnRemove(removed []observer.Endpoint) {
+	m.Lock()
+	defer m.Unlock()
+	m.removed = append(m.removed, removed...)
+}
+
+func (m *mockNotify) OnChange(changed []observer.Endpoint) {
+	m.Lock()
+	defer m.Unlock()
+	m.changed = append(m.changed, changed...)
+}
+
+func (m *mockNotify) counts() (int, int, int) {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.added), len(m.removed), len(m.changed)
+}
+
+func newContainerJSON() *dtypes.ContainerJSON {
+	return &dtypes.ContainerJSON{
+		ContainerJSONBase: &dtypes.ContainerJSONBase{
+			ID:    containerID,
+			Name:  "/redis-server",
+			Path:  "redis-server",
+			Args:  []string{"--port", "6379"},
+			State: &dtypes.ContainerState{Running: true},
+		},
+		Config: &container.Config{
+			Image:  "redis:6",
+			Labels: map[string]string{"app": "redis"},
+			ExposedPorts: nat.PortSet{
+				"6379/tcp": struct{}{},
+				"5000/udp": struct{}{},
+			},
+		},
+		NetworkSettings: &dtypes.NetworkSettings{
+			NetworkSettingsBase: dtypes.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"6379/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "16379"}},
+					"5000/udp": nil,
+				},
+			},
+			DefaultNetworkSettings: dtypes.DefaultNetworkSettings{
+				IPAddress: "172.17.0.2",
+			},
+		},
+	}
+}
+
+func newTestObserver(t *testing.T, useHostBindings bool) *dockerObserver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.UseHostBindings = useHostBindings
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	return ext.(*dockerObserver)
+}
+
+func TestEndpointsForContainer(t *testing.T) {
+	redisDetails := observer.Container{
+		Name:        "redis-server",
+		Image:       "redis:6",
+		ContainerID: containerID,
+		Command:     "redis-server --port 6379",
+		Labels:      map[string]string{"app": "redis"},
+	}
+
+	udpDetails := redisDetails
+	udpDetails.Port = 5000
+	udpDetails.Host = "172.17.0.2"
+	udpDetails.Transport = observer.ProtocolUDP
+
+	tcpDetails := redisDetails
+	tcpDetails.Port = 6379
+	tcpDetails.HostPort = 16379
+	tcpDetails.Host = "172.17.0.2"
+	tcpDetails.Transport = observer.ProtocolTCP
+
+	hostDetails := tcpDetails
+	hostDetails.Host = "127.0.0.1"
+
+	tests := []struct {
+		name            string
+		container       func() *dtypes.ContainerJSON
+		useHostBindings bool
+		want            []observer.Endpoint
+	}{
+		{
+			name:      "exposed ports",
+			container: newContainerJSON,
+			want: []observer.Endpoint{
+				{
+					ID:      "(docker_observer)3f4b2e8a9c1d:5000/udp",
+					Target:  "172.17.0.2:5000",
+					Details: udpDetails,
+				},
+				{
+					ID:      "(docker_observer)3f4b2e8a9c1d:6379/tcp",
+					Target:  "172.17.0.2:6379",
+					Details: tcpDetails,
+				},
+			},
+		},
+		{
+			name:            "host bindings",
+			container:       newContainerJSON,
+			useHostBindings: true,
+			want: []observer.Endpoint{
+				{
+					ID:      "(docker_observer)3f4b2e8a9c1d:6379/tcp-127.0.0.1:16379",
+					Target:  "127.0.0.1:16379",
+					Details: hostDetails,
+				},
+			},
+		},
+		{
+			name: "user defined network",
+			container: func() *dtypes.ContainerJSON {
+				c := newContainerJSON()
+				c.Config.ExposedPorts = nat.PortSet{"6379/tcp": struct{}{}}
+				c.NetworkSettings.IPAddress = ""
+				c.NetworkSettings.Networks = map[string]*network.EndpointSettings{
+					"backend": {IPAddress: "10.0.0.5"},
+				}
+				return c
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     "(docker_observer)3f4b2e8a9c1d:6379/tcp",
+					Target: "10.0.0.5:6379",
+					Details: func() observer.Container {
+						d := tcpDetails
+						d.Host = "10.0.0.5"
+						return d
+					}(),
+				},
+			},
+		},
+		{
+			name: "paused container",
+			container: func() *dtypes.ContainerJSON {
+				c := newContainerJSON()
+				c.State.Paused = true
+				return c
+			},
+		},
+		{
+			name: "stopped container",
+			container: func() *dtypes.ContainerJSON {
+				c := newContainerJSON()
+				c.State.Running = false
+				return c
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestObserver(t, tt.useHostBindings)
+			assert.Equal(t, tt.want, d.endpointsForContainer(tt.container()))
+		})
+	}
+}
+
+func TestUpdateContainer(t *testing.T) {
+	// prepare
+	d := newTestObserver(t, false)
+	notify := &mockNotify{}
+	w := &containerWatcher{
+		observer:  d,
+		listener:  notify,
+		endpoints: map[string]map[observer.EndpointID]observer.Endpoint{},
+	}
+	c := newContainerJSON()
+
+	// test
+	w.updateContainer(containerID, d.endpointsForContainer(c))
+
+	// verify
+	added, removed, changed := notify.counts()
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 0, removed)
+	assert.Equal(t, 0, changed)
+
+	// test
+	c.Name = "/renamed"
+	c.Config.ExposedPorts = nat.PortSet{"6379/tcp": struct{}{}}
+	w.updateContainer(containerID, d.endpointsForContainer(c))
+
+	// verify
+	added, removed, changed = notify.counts()
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, "renamed", notify.changed[0].Details.(observer.Container).Name)
+
+	// test
+	w.updateContainer(containerID, nil)
+
+	// verify
+	added, removed, changed = notify.counts()
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 1, changed)
+	assert.Empty(t, w.endpoints)
+}
+
+func TestListAndWatch(t *testing.T) {
+	// prepare
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.22/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]dtypes.Container{{ID: containerID}})
+	})
+	mux.HandleFunc(fmt.Sprintf("/v1.22/containers/%s/json", containerID), func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(newContainerJSON())
+	})
+	mux.HandleFunc("/v1.22/events", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(events.Message{
+			Type:     "container",
+			Action:   "die",
+			ID:       containerID,
+			TimeNano: time.Now().UnixNano(),
+		})
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: string(typeStr),
+		},
+		Endpoint: "tcp://" + strings.TrimPrefix(srv.URL, "http://"),
+		Timeout:  defaultTimeout,
+	}
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	notify := &mockNotify{}
+
+	// test
+	ext.(observer.Observable).ListAndWatch(notify)
+	defer ext.Shutdown(context.Background())
+
+	// verify
+	require.Eventually(t, func() bool {
+		_, removed, _ := notify.counts()
+		return removed == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	notify.Lock()
+	defer notify.Unlock()
+	require.Len(t, notify.added, 2)
+	assert.Equal(t, "172.17.0.2:5000", notify.added[0].Target)
+	assert.Equal(t, "172.17.0.2:6379", notify.added[1].Target)
+	assert.ElementsMatch(t, notify.added, notify.removed)
+}
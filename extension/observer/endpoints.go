@@ -36,6 +36,10 @@ func (e *Endpoint) String() string {
 type Pod struct {
 	// Name of the pod.
 	Name string
+	// Namespace of the pod.
+	Namespace string
+	// UID is the unique ID of the pod.
+	UID string
 	// Labels is a map of user-specified metadata.
 	Labels map[string]string
 	// Annotations is a map of user-specified metadata.
@@ -70,13 +74,74 @@ type HostPort struct {
 	IsIPv6 bool
 }
 
+// Container is a discovered docker container port.
+type Container struct {
+	// Name is the primary name of the container.
+	Name string
+	// Image is the name of the container image.
+	Image string
+	// ContainerID is the id of the container exposing the Endpoint.
+	ContainerID string
+	// Command used to invoke the process running in the container.
+	Command string
+	// Labels is a map of user-specified metadata on the container.
+	Labels map[string]string
+	// Port is the port exposed by the container.
+	Port uint16
+	// HostPort is the port of the host the container port is bound to, if any.
+	HostPort uint16
+	// Host is the hostname or IP address of the Endpoint.
+	Host string
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+}
+
+// Service is a discovered k8s service port.
+type Service struct {
+	// Name of the service.
+	Name string
+	// Namespace of the service.
+	Namespace string
+	// Labels is a map of user-specified metadata on the service.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata on the service.
+	Annotations map[string]string
+	// ClusterIP is the virtual IP address of the service.
+	ClusterIP string
+	// PortName is the name of the service port.
+	PortName string
+	// Port is the port number exposed by the service.
+	Port uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+}
+
+// Node is a discovered k8s node.
+type Node struct {
+	// Name of the node.
+	Name string
+	// Labels is a map of user-specified metadata on the node.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata on the node.
+	Annotations map[string]string
+	// InternalIP is the internal IP address of the node.
+	InternalIP string
+	// Hostname is the hostname of the node as reported by the kubelet.
+	Hostname string
+	// KubeletEndpointPort is the port the kubelet is listening on.
+	KubeletEndpointPort uint16
+}
+
 type EndpointEnv map[string]interface{}
 
 // EndpointToEnv converts an endpoint into a map suitable for expr evaluation.
 func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 	ruleTypes := map[string]interface{}{
-		"port": false,
-		"pod":  false,
+		"port":      false,
+		"pod":       false,
+		"container": false,
+		"service":   false,
+		"node":      false,
 	}
 
 	switch o := endpoint.Details.(type) {
@@ -86,6 +151,8 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"type":        ruleTypes,
 			"endpoint":    endpoint.Target,
 			"name":        o.Name,
+			"namespace":   o.Namespace,
+			"uid":         o.UID,
 			"labels":      o.Labels,
 			"annotations": o.Annotations,
 		}, nil
@@ -98,6 +165,8 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"port":     o.Port,
 			"pod": map[string]interface{}{
 				"name":        o.Pod.Name,
+				"namespace":   o.Pod.Namespace,
+				"uid":         o.Pod.UID,
 				"labels":      o.Pod.Labels,
 				"annotations": o.Pod.Annotations,
 			},
@@ -114,6 +183,47 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"port":      o.Port,
 			"transport": o.Transport,
 		}, nil
+	case Container:
+		ruleTypes["container"] = true
+		return map[string]interface{}{
+			"type":         ruleTypes,
+			"endpoint":     endpoint.Target,
+			"name":         o.Name,
+			"image":        o.Image,
+			"container_id": o.ContainerID,
+			"command":      o.Command,
+			"labels":       o.Labels,
+			"port":         o.Port,
+			"host_port":    o.HostPort,
+			"host":         o.Host,
+			"transport":    o.Transport,
+		}, nil
+	case Service:
+		ruleTypes["service"] = true
+		return map[string]interface{}{
+			"type":        ruleTypes,
+			"endpoint":    endpoint.Target,
+			"name":        o.Name,
+			"namespace":   o.Namespace,
+			"labels":      o.Labels,
+			"annotations": o.Annotations,
+			"cluster_ip":  o.ClusterIP,
+			"port_name":   o.PortName,
+			"port":        o.Port,
+			"transport":   o.Transport,
+		}, nil
+	case Node:
+		ruleTypes["node"] = true
+		return map[string]interface{}{
+			"type":                  ruleTypes,
+			"endpoint":              endpoint.Target,
+			"name":                  o.Name,
+			"labels":                o.Labels,
+			"annotations":           o.Annotations,
+			"internal_ip":           o.InternalIP,
+			"hostname":              o.Hostname,
+			"kubelet_endpoint_port": o.KubeletEndpointPort,
+		}, nil
 
 	default:
 		return nil, fmt.Errorf("unknown endpoint details type %T", endpoint.Details)
@@ -32,7 +32,9 @@ func TestEndpointToEnv(t *testing.T) {
 				ID:     EndpointID("pod_id"),
 				Target: "192.68.73.2",
 				Details: Pod{
-					Name: "pod_name",
+					Name:      "pod_name",
+					Namespace: "pod_namespace",
+					UID:       "pod_uid",
 					Labels: map[string]string{
 						"label_key": "label_val",
 					},
@@ -43,11 +45,16 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": false,
-					"pod":  true,
+					"port":      false,
+					"pod":       true,
+					"container": false,
+					"service":   false,
+					"node":      false,
 				},
-				"endpoint": "192.68.73.2",
-				"name":     "pod_name",
+				"endpoint":  "192.68.73.2",
+				"name":      "pod_name",
+				"namespace": "pod_namespace",
+				"uid":       "pod_uid",
 				"labels": map[string]string{
 					"label_key": "label_val",
 				},
@@ -65,7 +72,9 @@ func TestEndpointToEnv(t *testing.T) {
 				Details: Port{
 					Name: "port_name",
 					Pod: Pod{
-						Name: "pod_name",
+						Name:      "pod_name",
+						Namespace: "pod_namespace",
+						UID:       "pod_uid",
 						Labels: map[string]string{
 							"label_key": "label_val",
 						},
@@ -79,14 +88,19 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": true,
-					"pod":  false,
+					"port":      true,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
 				},
 				"endpoint": "192.68.73.2",
 				"name":     "port_name",
 				"port":     uint16(2379),
 				"pod": map[string]interface{}{
-					"name": "pod_name",
+					"name":      "pod_name",
+					"namespace": "pod_namespace",
+					"uid":       "pod_uid",
 					"labels": map[string]string{
 						"label_key": "label_val",
 					},
@@ -113,8 +127,11 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": true,
-					"pod":  false,
+					"port":      true,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
 				},
 				"endpoint":  "127.0.0.1",
 				"name":      "process_name",
@@ -125,6 +142,132 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Container",
+			endpoint: Endpoint{
+				ID:     EndpointID("container_endpoint_id"),
+				Target: "127.0.0.1:8080",
+				Details: Container{
+					Name:        "otel-collector",
+					Image:       "otel-collector-image",
+					ContainerID: "container-id",
+					Command:     "./cmd --config config.yaml",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Port:      2379,
+					HostPort:  8080,
+					Host:      "127.0.0.1",
+					Transport: ProtocolTCP,
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": true,
+					"service":   false,
+					"node":      false,
+				},
+				"endpoint":     "127.0.0.1:8080",
+				"name":         "otel-collector",
+				"image":        "otel-collector-image",
+				"container_id": "container-id",
+				"command":      "./cmd --config config.yaml",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"port":      uint16(2379),
+				"host_port": uint16(8080),
+				"host":      "127.0.0.1",
+				"transport": ProtocolTCP,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("service_id"),
+				Target: "10.96.0.10:8080",
+				Details: Service{
+					Name:      "kube-state-metrics",
+					Namespace: "kube-system",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+					ClusterIP: "10.96.0.10",
+					PortName:  "http-metrics",
+					Port:      8080,
+					Transport: ProtocolTCP,
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   true,
+					"node":      false,
+				},
+				"endpoint":  "10.96.0.10:8080",
+				"name":      "kube-state-metrics",
+				"namespace": "kube-system",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+				"cluster_ip": "10.96.0.10",
+				"port_name":  "http-metrics",
+				"port":       uint16(8080),
+				"transport":  ProtocolTCP,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Node",
+			endpoint: Endpoint{
+				ID:     EndpointID("node_id"),
+				Target: "10.0.0.1:10250",
+				Details: Node{
+					Name: "node-1",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+					InternalIP:          "10.0.0.1",
+					Hostname:            "node-1.local",
+					KubeletEndpointPort: 10250,
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      true,
+				},
+				"endpoint": "10.0.0.1:10250",
+				"name":     "node-1",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+				"internal_ip":           "10.0.0.1",
+				"hostname":              "node-1.local",
+				"kubelet_endpoint_port": uint16(10250),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Unsupported endpoint",
 			endpoint: Endpoint{
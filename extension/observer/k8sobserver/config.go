@@ -36,4 +36,13 @@ type Config struct {
 	//
 	// Then set this value to ${K8S_NODE_NAME} in the configuration.
 	Node string `mapstructure:"node"`
+
+	// ObservePods determines whether pod and port endpoints are emitted. Default is true.
+	ObservePods bool `mapstructure:"observe_pods"`
+	// ObserveServices determines whether service endpoints, one for each port of a service
+	// with a cluster IP, are emitted. Services are watched across all namespaces.
+	ObserveServices bool `mapstructure:"observe_services"`
+	// ObserveNodes determines whether node endpoints targeting the kubelet are emitted. If Node
+	// is set only that node is observed.
+	ObserveNodes bool `mapstructure:"observe_nodes"`
 }
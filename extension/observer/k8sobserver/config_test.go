@@ -50,8 +50,11 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "k8s_observer",
 				NameVal: "k8s_observer/1",
 			},
-			Node:      "node-1",
-			APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			Node:            "node-1",
+			APIConfig:       k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			ObservePods:     true,
+			ObserveServices: true,
+			ObserveNodes:    true,
 		},
 		ext1)
 }
@@ -30,7 +30,7 @@ import (
 func TestNewExtension(t *testing.T) {
 	listWatch := framework.NewFakeControllerSource()
 	factory := &Factory{}
-	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch)
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 }
@@ -38,7 +38,7 @@ func TestNewExtension(t *testing.T) {
 func TestExtensionObserve(t *testing.T) {
 	listWatch := framework.NewFakeControllerSource()
 	factory := &Factory{}
-	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch)
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 	obs := ext.(*k8sObserver)
@@ -58,7 +58,9 @@ func TestExtensionObserve(t *testing.T) {
 		ID:     "k8s_observer/pod1-UID",
 		Target: "1.2.3.4",
 		Details: observer.Pod{
-			Name: "pod1",
+			Name:      "pod1",
+			Namespace: "default",
+			UID:       "pod1-UID",
 			Labels: map[string]string{
 				"env": "prod",
 			},
@@ -75,7 +77,9 @@ func TestExtensionObserve(t *testing.T) {
 		ID:     "k8s_observer/pod1-UID",
 		Target: "1.2.3.4",
 		Details: observer.Pod{
-			Name: "pod1",
+			Name:      "pod1",
+			Namespace: "default",
+			UID:       "pod1-UID",
 			Labels: map[string]string{
 				"env":         "prod",
 				"pod-version": "2",
@@ -85,3 +89,46 @@ func TestExtensionObserve(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServicesAndNodes(t *testing.T) {
+	serviceListWatch := framework.NewFakeControllerSource()
+	nodeListWatch := framework.NewFakeControllerSource()
+	factory := &Factory{}
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), nil, serviceListWatch, nodeListWatch)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+	obs := ext.(*k8sObserver)
+	require.Len(t, obs.informers, 2)
+
+	serviceListWatch.Add(service1)
+	nodeListWatch.Add(node1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	assertSink(t, sink, func() bool {
+		return len(sink.added) == 3
+	})
+
+	sink.Lock()
+	ids := make([]observer.EndpointID, 0, len(sink.added))
+	for _, e := range sink.added {
+		ids = append(ids, e.ID)
+	}
+	sink.Unlock()
+	assert.ElementsMatch(t, []observer.EndpointID{
+		"k8s_observer/service-1-UID/http-metrics(8080)",
+		"k8s_observer/service-1-UID/dns(53)",
+		"k8s_observer/node-1-UID",
+	}, ids)
+
+	serviceListWatch.Delete(service1)
+
+	assertSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -16,6 +16,7 @@ package k8sobserver
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,6 +34,8 @@ const (
 	typeStr configmodels.Type = "k8s_observer"
 )
 
+var errNothingToObserve = errors.New("at least one of observe_pods, observe_services or observe_nodes must be enabled")
+
 // Factory is the factory for the extension.
 type Factory struct {
 	// createK8sClientset being a field in the struct provides an easy way
@@ -54,7 +57,8 @@ func (f *Factory) CreateDefaultConfig() configmodels.Extension {
 			TypeVal: typeStr,
 			NameVal: string(typeStr),
 		},
-		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		ObservePods: true,
 	}
 }
 
@@ -65,17 +69,36 @@ func (f *Factory) CreateExtension(
 	cfg configmodels.Extension,
 ) (component.ServiceExtension, error) {
 	config := cfg.(*Config)
+	if !config.ObservePods && !config.ObserveServices && !config.ObserveNodes {
+		return nil, errNothingToObserve
+	}
 
 	clientset, err := f.createK8sClientset(config.APIConfig)
 	if err != nil {
 		return nil, err
 	}
+	restClient := clientset.CoreV1().RESTClient()
 
-	listWatch := cache.NewListWatchFromClient(
-		clientset.CoreV1().RESTClient(), "pods", v1.NamespaceAll,
-		fields.OneTermEqualSelector("spec.nodeName", config.Node))
+	var podListWatch, serviceListWatch, nodeListWatch cache.ListerWatcher
+	if config.ObservePods {
+		podListWatch = cache.NewListWatchFromClient(
+			restClient, "pods", v1.NamespaceAll,
+			fields.OneTermEqualSelector("spec.nodeName", config.Node))
+	}
+	if config.ObserveServices {
+		serviceListWatch = cache.NewListWatchFromClient(
+			restClient, "services", v1.NamespaceAll, fields.Everything())
+	}
+	if config.ObserveNodes {
+		nodeSelector := fields.Everything()
+		if config.Node != "" {
+			nodeSelector = fields.OneTermEqualSelector("metadata.name", config.Node)
+		}
+		nodeListWatch = cache.NewListWatchFromClient(
+			restClient, "nodes", v1.NamespaceAll, nodeSelector)
+	}
 
-	return newObserver(params.Logger, config, listWatch)
+	return newObserver(params.Logger, config, podListWatch, serviceListWatch, nodeListWatch)
 }
 
 // NewFactory should be called to create a factory with default values.
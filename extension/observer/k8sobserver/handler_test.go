@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
@@ -34,8 +37,10 @@ func TestEndpointsAdded(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod"},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod"},
 			},
 		}, {
 			ID:     "test-1/pod-2-UID/https(443)",
@@ -43,8 +48,10 @@ func TestEndpointsAdded(t *testing.T) {
 			Details: observer.Port{
 				Name: "https",
 				Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod"},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod"},
 				},
 				Port:      443,
 				Transport: observer.ProtocolTCP,
@@ -66,8 +73,10 @@ func TestEndpointsRemoved(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod"},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod"},
 			},
 		}, {
 			ID:     "test-1/pod-2-UID/https(443)",
@@ -75,8 +84,10 @@ func TestEndpointsRemoved(t *testing.T) {
 			Details: observer.Port{
 				Name: "https",
 				Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod"},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod"},
 				},
 				Port:      443,
 				Transport: observer.ProtocolTCP,
@@ -121,16 +132,108 @@ func TestEndpointsChanged(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod", "updated-label": "true"}}},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod", "updated-label": "true"}}},
 		{
 			ID:     "test-1/pod-2-UID/https(443)",
 			Target: "1.2.3.4:443",
 			Details: observer.Port{
 				Name: "https", Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod", "updated-label": "true"}},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod", "updated-label": "true"}},
 				Port:      443,
 				Transport: observer.ProtocolTCP}},
 	}, sink.changed)
 }
+
+func TestServiceEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	details := observer.Service{
+		Name:        "service-1",
+		Namespace:   "default",
+		Labels:      map[string]string{"env": "prod"},
+		Annotations: map[string]string{"prometheus.io/scrape": "true"},
+		ClusterIP:   "10.96.0.10",
+	}
+	httpDetails := details
+	httpDetails.PortName = "http-metrics"
+	httpDetails.Port = 8080
+	httpDetails.Transport = observer.ProtocolTCP
+	dnsDetails := details
+	dnsDetails.PortName = "dns"
+	dnsDetails.Port = 53
+	dnsDetails.Transport = observer.ProtocolUDP
+	expected := []observer.Endpoint{
+		{
+			ID:      "test-1/service-1-UID/http-metrics(8080)",
+			Target:  "10.96.0.10:8080",
+			Details: httpDetails,
+		}, {
+			ID:      "test-1/service-1-UID/dns(53)",
+			Target:  "10.96.0.10:53",
+			Details: dnsDetails,
+		},
+	}
+
+	h.OnAdd(service1)
+	assert.ElementsMatch(t, expected, sink.added)
+
+	h.OnDelete(service1)
+	assert.ElementsMatch(t, expected, sink.removed)
+	assert.Nil(t, sink.changed)
+}
+
+func TestHeadlessServiceHasNoEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	headless := service1.DeepCopy()
+	headless.Spec.ClusterIP = v1.ClusterIPNone
+
+	h.OnAdd(headless)
+	h.OnDelete(headless)
+	assert.Nil(t, sink.added)
+	assert.Nil(t, sink.removed)
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	h.OnAdd(node1)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "test-1/node-1-UID",
+			Target: "10.0.0.1:10250",
+			Details: observer.Node{
+				Name:                "node-1",
+				Labels:              map[string]string{"env": "prod"},
+				InternalIP:          "10.0.0.1",
+				Hostname:            "node-1.local",
+				KubeletEndpointPort: 10250,
+			},
+		}}, sink.added)
+
+	// Labels changed.
+	changedLabels := node1.DeepCopy()
+	changedLabels.Labels["new-label"] = "value"
+	h.OnUpdate(node1, changedLabels)
+	require.Len(t, sink.changed, 1)
+	assert.Equal(t, "value", sink.changed[0].Details.(observer.Node).Labels["new-label"])
+
+	h.OnDelete(cache.DeletedFinalStateUnknown{Key: "node-1", Obj: changedLabels})
+	require.Len(t, sink.removed, 1)
+	assert.Equal(t, observer.EndpointID("test-1/node-1-UID"), sink.removed[0].ID)
+}
@@ -110,3 +110,53 @@ var podWithNamedPorts = func() *v1.Pod {
 	}
 	return pod
 }()
+
+// NewService is a helper function for creating Services for testing.
+func NewService(name string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+			Annotations: map[string]string{
+				"prometheus.io/scrape": "true",
+			},
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "10.96.0.10",
+			Ports: []v1.ServicePort{
+				{Name: "http-metrics", Port: 8080, Protocol: v1.ProtocolTCP},
+				{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+}
+
+var service1 = NewService("service-1")
+
+// NewNode is a helper function for creating Nodes for testing.
+func NewNode(name, hostname string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			UID:  types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeHostName, Address: hostname},
+				{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+			DaemonEndpoints: v1.NodeDaemonEndpoints{
+				KubeletEndpoint: v1.DaemonEndpoint{Port: 10250},
+			},
+		},
+	}
+}
+
+var node1 = NewNode("node-1", "node-1.local")
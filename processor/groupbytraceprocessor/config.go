@@ -35,12 +35,22 @@ type Config struct {
 	// DiscardOrphans instructs the processor to discard traces without the root span.
 	// This typically indicates that the trace is incomplete.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
+	// OrphanResourceAttribute is the name of a resource attribute to be set to true on traces without the root span
+	// when they are released, so that the next components can handle them differently, such as routing them to
+	// a different exporter. Ignored when DiscardOrphans is enabled.
+	// Default: "" (not set).
+	OrphanResourceAttribute string `mapstructure:"orphan_resource_attribute"`
+
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
-	// Useful when the duration to wait for traces to complete is high.
+	// Useful when the duration to wait for traces to complete is high. Traces stored on disk are recovered
+	// when the processor starts again.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageDirectory is the directory where the trace spans are stored when StoreOnDisk is enabled.
+	// Each instance of the processor should have its own directory.
+	// Default: <temp dir>/otelcol-groupbytrace/<processor name>.
+	StorageDirectory string `mapstructure:"storage_directory"`
 }
@@ -16,7 +16,8 @@ package groupbytraceprocessor
 
 import (
 	"context"
-	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -36,9 +37,6 @@ var (
 	defaultNumTraces      = 1_000_000
 	defaultDiscardOrphans = false
 	defaultStoreOnDisk    = false
-
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
 )
 
 // NewFactory returns a new factory for the Filter processor.
@@ -62,7 +60,6 @@ func createDefaultConfig() configmodels.Processor {
 		NumTraces:    defaultNumTraces,
 		WaitDuration: defaultWaitDuration,
 
-		// not supported for now
 		DiscardOrphans: defaultDiscardOrphans,
 		StoreOnDisk:    defaultStoreOnDisk,
 	}
@@ -79,14 +76,14 @@ func createTraceProcessor(
 
 	var st storage
 	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
-	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
+		directory := oCfg.StorageDirectory
+		if len(directory) == 0 {
+			directory = filepath.Join(os.TempDir(), "otelcol-groupbytrace", oCfg.Name())
+		}
+		st = newDiskStorage(params.Logger, directory)
+	} else {
+		st = newMemoryStorage()
 	}
 
-	// the only supported storage for now
-	st = newMemoryStorage()
-
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg)
 }
@@ -16,9 +16,12 @@ package groupbytraceprocessor
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -49,36 +52,24 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
-	// prepare
-	f := NewFactory()
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+	dir, err := ioutil.TempDir("", "groupbytrace")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	c.StorageDirectory = dir
+
 	params := component.ProcessorCreateParams{
 		Logger: logger,
 	}
 	next := &mockProcessor{}
 
 	// test
-	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
-	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
-	} {
-		p, err := f.CreateTracesProcessor(context.Background(), params, tt.config, next)
+	p, err := createTraceProcessor(context.Background(), params, c, next)
 
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
-	}
+	// verify
+	assert.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
 }
@@ -29,9 +29,14 @@ var (
 	mReleasedSpans      = stats.Int64("processor_groupbytrace_spans_released", "Spans released to the next consumer", stats.UnitDimensionless)
 	mReleasedTraces     = stats.Int64("processor_groupbytrace_traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("processor_groupbytrace_incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
+	mOrphanTraces       = stats.Int64("processor_groupbytrace_orphan_traces", "Expired traces without a root span", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("processor_groupbytrace_event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
 )
 
+var (
+	tagOrphanAction = tag.MustNewKey("action")
+)
+
 // MetricViews return the metrics views according to given telemetry level.
 func MetricViews() []*view.View {
 	legacyViews := []*view.View{
@@ -78,6 +83,15 @@ func MetricViews() []*view.View {
 			Description: mIncompleteReleases.Description(),
 			Aggregation: view.Sum(),
 		},
+		{
+			Name:        mOrphanTraces.Name(),
+			Measure:     mOrphanTraces,
+			Description: mOrphanTraces.Description(),
+			TagKeys: []tag.Key{
+				tagOrphanAction,
+			},
+			Aggregation: view.Sum(),
+		},
 		{
 			Name:        mEventLatency.Name(),
 			Measure:     mEventLatency,
@@ -21,12 +21,24 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
 
+const (
+	// orphanActionDiscarded is used when a trace without a root span is not released
+	orphanActionDiscarded = "discarded"
+
+	// orphanActionMarked is used when a trace without a root span is released with the orphan resource attribute
+	orphanActionMarked = "marked"
+
+	// orphanActionReleased is used when a trace without a root span is released as is
+	orphanActionReleased = "released"
+)
+
 var (
 	errNilResourceSpans = errors.New("invalid resource spans (nil)")
 )
@@ -101,8 +113,13 @@ func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(); err != nil {
+		return err
+	}
+
+	sp.recoverTraces()
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -172,6 +189,12 @@ func (sp *groupByTraceProcessor) processBatch(batch *singleTraceBatch) error {
 		return fmt.Errorf("couldn't add spans to new trace: %w", err)
 	}
 
+	sp.scheduleRelease(traceID)
+	return nil
+}
+
+// scheduleRelease fires the expiration event for the given trace once the wait duration has passed
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pdata.TraceID) {
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
 	time.AfterFunc(sp.config.WaitDuration, func() {
@@ -181,8 +204,35 @@ func (sp *groupByTraceProcessor) processBatch(batch *singleTraceBatch) error {
 			payload: traceID,
 		})
 	})
+}
 
-	return nil
+// recoverTraces places the traces that were already in the storage when the processor started into the
+// ring buffer, scheduling their release as if they had just been received. This is only done for storages
+// that are able to keep traces across restarts, and happens before the event machine starts.
+func (sp *groupByTraceProcessor) recoverTraces() {
+	rst, ok := sp.st.(recoverableStorage)
+	if !ok {
+		return
+	}
+
+	traceIDs := rst.traceIDs()
+	for _, traceID := range traceIDs {
+		evicted := sp.ringBuffer.put(traceID)
+		if evicted.IsValid() {
+			// the event machine isn't running yet, so, we remove the evicted trace right away
+			if _, err := sp.st.delete(evicted); err != nil {
+				sp.logger.Warn("failed to remove evicted trace from the storage", zap.Error(err),
+					zap.String("traceID", evicted.HexString()))
+			}
+			stats.Record(context.Background(), mTracesEvicted.M(1))
+		}
+
+		sp.scheduleRelease(traceID)
+	}
+
+	if len(traceIDs) > 0 {
+		sp.logger.Info("recovered traces from the storage", zap.Int("traces", len(traceIDs)))
+	}
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pdata.TraceID) error {
@@ -221,6 +271,27 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pdata.TraceID) error {
 		return fmt.Errorf("the trace %q couldn't be found at the storage", traceID)
 	}
 
+	if !hasRootSpan(trace) {
+		if sp.config.DiscardOrphans {
+			sp.logger.Debug("discarding trace without a root span", zap.String("traceID", traceID.HexString()))
+			recordOrphanTrace(orphanActionDiscarded)
+
+			// the trace isn't released, but still has to be removed from the storage
+			sp.eventMachine.fire(event{
+				typ:     traceRemoved,
+				payload: traceID,
+			})
+			return nil
+		}
+
+		if len(sp.config.OrphanResourceAttribute) > 0 {
+			markAsOrphan(trace, sp.config.OrphanResourceAttribute)
+			recordOrphanTrace(orphanActionMarked)
+		} else {
+			recordOrphanTrace(orphanActionReleased)
+		}
+	}
+
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.String("traceID", traceID.HexString()))
 
@@ -264,6 +335,38 @@ func (sp *groupByTraceProcessor) addSpans(traceID pdata.TraceID, trace pdata.Res
 	return sp.st.createOrAppend(traceID, trace)
 }
 
+// hasRootSpan checks whether any of the spans from the trace has no parent span
+func hasRootSpan(rss []pdata.ResourceSpans) bool {
+	for _, rs := range rss {
+		for i := 0; i < rs.InstrumentationLibrarySpans().Len(); i++ {
+			spans := rs.InstrumentationLibrarySpans().At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				if !spans.At(j).ParentSpanID().IsValid() {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// markAsOrphan sets the given attribute to true on all the resources from the trace
+func markAsOrphan(rss []pdata.ResourceSpans, attribute string) {
+	for _, rs := range rss {
+		resource := rs.Resource()
+		if resource.IsNil() {
+			resource.InitEmpty()
+		}
+		resource.Attributes().UpsertBool(attribute, true)
+	}
+}
+
+func recordOrphanTrace(action string) {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tagOrphanAction, action))
+	stats.Record(ctx, mOrphanTraces.M(1))
+}
+
 type singleTraceBatch struct {
 	traceID pdata.TraceID
 	rs      pdata.ResourceSpans
@@ -17,6 +17,8 @@ package groupbytraceprocessor
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -77,6 +79,173 @@ func TestTraceIsDispatchedAfterDuration(t *testing.T) {
 	wgDeleted.Wait()
 }
 
+func TestOrphanTraceIsDiscarded(t *testing.T) {
+	// prepare
+	traces := simpleTraces()
+	traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetParentSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4}))
+
+	config := Config{
+		WaitDuration:   time.Nanosecond,
+		NumTraces:      10,
+		DiscardOrphans: true,
+	}
+	mockProcessor := &mockProcessor{
+		onTraces: func(ctx context.Context, received pdata.Traces) error {
+			assert.Fail(t, "the orphan trace should not have been released")
+			return nil
+		},
+	}
+
+	wgDeleted := &sync.WaitGroup{}
+	backing := newMemoryStorage()
+	st := &mockStorage{
+		onCreateOrAppend: backing.createOrAppend,
+		onGet:            backing.get,
+		onDelete: func(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+			wgDeleted.Done()
+			return backing.delete(traceID)
+		},
+	}
+
+	p, err := newGroupByTraceProcessor(logger, st, mockProcessor, config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	p.Start(ctx, nil)
+	defer p.Shutdown(ctx)
+
+	// test
+	wgDeleted.Add(1)
+	p.ConsumeTraces(ctx, traces)
+
+	// verify
+	wgDeleted.Wait()
+	assert.Equal(t, 0, backing.count())
+}
+
+func TestOrphanTraceIsMarked(t *testing.T) {
+	// prepare
+	traces := simpleTraces()
+	traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetParentSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4}))
+
+	wgReceived := &sync.WaitGroup{}
+	config := Config{
+		WaitDuration:            time.Nanosecond,
+		NumTraces:               10,
+		OrphanResourceAttribute: "incomplete",
+	}
+	mockProcessor := &mockProcessor{
+		onTraces: func(ctx context.Context, received pdata.Traces) error {
+			value, found := received.ResourceSpans().At(0).Resource().Attributes().Get("incomplete")
+			assert.True(t, found)
+			assert.True(t, value.BoolVal())
+			wgReceived.Done()
+			return nil
+		},
+	}
+
+	p, err := newGroupByTraceProcessor(logger, newMemoryStorage(), mockProcessor, config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	p.Start(ctx, nil)
+	defer p.Shutdown(ctx)
+
+	// test
+	wgReceived.Add(1)
+	p.ConsumeTraces(ctx, traces)
+
+	// verify
+	wgReceived.Wait()
+}
+
+func TestHasRootSpan(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		parents  [][8]byte
+		expected bool
+	}{
+		{
+			"single root span",
+			[][8]byte{{}},
+			true,
+		},
+		{
+			"root span and child",
+			[][8]byte{{1, 2, 3, 4}, {}},
+			true,
+		},
+		{
+			"only children",
+			[][8]byte{{1, 2, 3, 4}, {2, 3, 4, 5}},
+			false,
+		},
+		{
+			"no spans",
+			[][8]byte{},
+			false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			rs := pdata.NewResourceSpans()
+			rs.InitEmpty()
+			rs.InstrumentationLibrarySpans().Resize(1)
+			spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+			spans.Resize(len(tt.parents))
+			for i, parent := range tt.parents {
+				spans.At(i).SetParentSpanID(pdata.NewSpanID(parent))
+			}
+
+			// test
+			found := hasRootSpan([]pdata.ResourceSpans{rs})
+
+			// verify
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+}
+
+func TestTracesAreRecoveredFromStorage(t *testing.T) {
+	// prepare
+	dir, err := ioutil.TempDir("", "groupbytrace")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the trace is in the storage from a previous run
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	previous := newDiskStorage(zap.NewNop(), dir)
+	require.NoError(t, previous.start())
+	require.NoError(t, previous.createOrAppend(traceID, simpleTracesWithID(traceID).ResourceSpans().At(0)))
+	require.NoError(t, previous.shutdown())
+
+	wgReceived := &sync.WaitGroup{}
+	config := Config{
+		WaitDuration: time.Nanosecond,
+		NumTraces:    10,
+	}
+	mockProcessor := &mockProcessor{
+		onTraces: func(ctx context.Context, received pdata.Traces) error {
+			assert.Equal(t, traceID, received.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+			wgReceived.Done()
+			return nil
+		},
+	}
+
+	st := newDiskStorage(zap.NewNop(), dir)
+	p, err := newGroupByTraceProcessor(logger, st, mockProcessor, config)
+	require.NoError(t, err)
+
+	// test
+	wgReceived.Add(1)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, nil))
+	defer p.Shutdown(ctx)
+
+	// verify
+	wgReceived.Wait()
+}
+
 func TestInternalCacheLimit(t *testing.T) {
 	// prepare
 	wg := &sync.WaitGroup{} // we wait for the next (mock) processor to receive the trace
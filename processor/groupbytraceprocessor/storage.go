@@ -40,3 +40,10 @@ type storage interface {
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is implemented by storages that are able to keep the traces across restarts.
+// The processor schedules the release of the recovered traces when it starts.
+type recoverableStorage interface {
+	// traceIDs returns the IDs for all the traces currently in the storage
+	traceIDs() []pdata.TraceID
+}
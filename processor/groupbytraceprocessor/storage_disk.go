@@ -0,0 +1,674 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// recordTypeSpans marks a record holding serialized resource spans for a trace
+	recordTypeSpans byte = 1
+
+	// recordTypeTombstone marks a record stating that all the previous records for a trace are gone
+	recordTypeTombstone byte = 2
+
+	// the record header is made of the record type, the trace ID and the payload length
+	recordHeaderSize = 1 + 16 + 4
+
+	// the record trailer is the CRC32 checksum of the header and payload
+	recordTrailerSize = 4
+
+	segmentFilePrefix = "segment-"
+	segmentFileSuffix = ".log"
+
+	defaultMaxSegmentSize      int64 = 16 * 1024 * 1024
+	defaultCompactionThreshold       = 0.25
+)
+
+var (
+	errStorageCorruptedRecord = errors.New("the record is corrupted")
+	errStorageClosed          = errors.New("the storage has been shut down")
+)
+
+// recordLocation points to a record holding spans for a trace in one of the segments
+type recordLocation struct {
+	segment uint64
+	offset  int64
+	size    int64
+
+	// checksum is the CRC32 checksum of the record, identifying the copies made by the compaction
+	checksum uint32
+}
+
+// segment is a single file of the append-only log
+type segment struct {
+	id   uint64
+	file *os.File
+	size int64
+
+	// live is the number of records in this segment holding spans for traces that are still in the storage
+	live int
+
+	// total is the number of records holding spans that have been written to this segment
+	total int
+}
+
+// diskStorage is a storage that keeps only the trace IDs and the location of their records in memory,
+// serializing the resource spans to an append-only log made of segment files. Removing a trace appends
+// a tombstone to the log, so that the state of the storage can be rebuilt by replaying the segments
+// when the processor starts again. Segments are removed from the oldest to the newest once they don't
+// hold live records anymore: segments with only a few live records left have them copied to the active
+// segment before being removed. When the collector stops between the copy and the removal, both copies
+// are found when replaying the segments, and the newest one wins.
+type diskStorage struct {
+	sync.Mutex
+	logger    *zap.Logger
+	directory string
+
+	maxSegmentSize      int64
+	compactionThreshold float64
+	compactionInterval  time.Duration
+
+	// index holds the location of the records for each of the traces in the storage
+	index map[[16]byte][]recordLocation
+
+	// segments holds the segments that haven't been removed yet, from the oldest to the newest.
+	// The newest segment is the active one, receiving the new records.
+	segments []*segment
+
+	// closed is set by shutdown, after which the segments can't be read or written anymore. Event
+	// handlers abandoned on timeout by the event machine may still call the storage after it.
+	closed bool
+
+	stopCh chan struct{}
+	stopWg sync.WaitGroup
+}
+
+var _ storage = (*diskStorage)(nil)
+var _ recoverableStorage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, directory string) *diskStorage {
+	return &diskStorage{
+		logger:              logger,
+		directory:           directory,
+		maxSegmentSize:      defaultMaxSegmentSize,
+		compactionThreshold: defaultCompactionThreshold,
+		compactionInterval:  time.Second,
+		index:               make(map[[16]byte][]recordLocation),
+		stopCh:              make(chan struct{}),
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pdata.TraceID, rs pdata.ResourceSpans) error {
+	if rs.IsNil() {
+		return errStorageNilResourceSpans
+	}
+
+	payload, err := marshalResourceSpans(rs)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize the resource spans: %w", err)
+	}
+
+	key := traceID.Bytes()
+
+	st.Lock()
+	defer st.Unlock()
+
+	if st.closed {
+		return errStorageClosed
+	}
+
+	loc, err := st.append(encodeRecord(recordTypeSpans, key, payload))
+	if err != nil {
+		return err
+	}
+
+	st.index[key] = append(st.index[key], loc)
+	return nil
+}
+
+func (st *diskStorage) get(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if st.closed {
+		return nil, errStorageClosed
+	}
+
+	locs, ok := st.index[traceID.Bytes()]
+	if !ok {
+		return nil, nil
+	}
+
+	return st.read(locs)
+}
+
+func (st *diskStorage) delete(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	key := traceID.Bytes()
+
+	st.Lock()
+	defer st.Unlock()
+
+	if st.closed {
+		return nil, errStorageClosed
+	}
+
+	locs, ok := st.index[key]
+	if !ok {
+		return nil, nil
+	}
+
+	result, err := st.read(locs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := st.append(encodeRecord(recordTypeTombstone, key, nil)); err != nil {
+		return nil, err
+	}
+	st.removeFromIndex(key)
+
+	return result, nil
+}
+
+func (st *diskStorage) start() error {
+	if err := os.MkdirAll(st.directory, 0700); err != nil {
+		return fmt.Errorf("couldn't create the storage directory %q: %w", st.directory, err)
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	if err := st.load(); err != nil {
+		return err
+	}
+
+	// new records always go to a new segment, so that we don't write after a record that had to be truncated
+	if err := st.rotate(); err != nil {
+		return err
+	}
+
+	st.stopWg.Add(1)
+	go st.periodicMaintenance()
+
+	st.logger.Debug("disk storage started",
+		zap.String("directory", st.directory),
+		zap.Int("traces", len(st.index)),
+		zap.Int("segments", len(st.segments)))
+
+	return nil
+}
+
+func (st *diskStorage) shutdown() error {
+	close(st.stopCh)
+	st.stopWg.Wait()
+
+	st.Lock()
+	defer st.Unlock()
+
+	var errs []error
+	for _, seg := range st.segments {
+		if err := seg.file.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := seg.file.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	st.segments = nil
+	st.closed = true
+
+	if len(errs) > 0 {
+		return fmt.Errorf("couldn't close the segments: %v", errs)
+	}
+	return nil
+}
+
+// traceIDs returns the IDs for all the traces in the storage, including the ones recovered from
+// the segments when the storage was started
+func (st *diskStorage) traceIDs() []pdata.TraceID {
+	st.Lock()
+	defer st.Unlock()
+
+	result := make([]pdata.TraceID, 0, len(st.index))
+	for key := range st.index {
+		result = append(result, pdata.NewTraceID(key))
+	}
+	return result
+}
+
+func (st *diskStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.index)
+}
+
+func (st *diskStorage) periodicMaintenance() {
+	defer st.stopWg.Done()
+
+	ticker := time.NewTicker(st.compactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := st.compact(); err != nil {
+				st.logger.Warn("failed to compact the storage", zap.Error(err))
+			}
+			stats.Record(context.Background(), mNumTracesInMemory.M(int64(st.count())))
+		case <-st.stopCh:
+			return
+		}
+	}
+}
+
+// compact removes the oldest segments for as long as they have no live records, or only a few of them.
+// In the later case, the live records are copied to the active segment before the segment is removed.
+// Segments are only ever removed from the oldest to the newest, so that a tombstone is never removed
+// while the records it refers to are still around.
+func (st *diskStorage) compact() error {
+	st.Lock()
+	defer st.Unlock()
+
+	// the last segment is the active one, and is never removed
+	for len(st.segments) > 1 {
+		oldest := st.segments[0]
+		if oldest.live > 0 {
+			if float64(oldest.live)/float64(oldest.total) > st.compactionThreshold {
+				return nil
+			}
+
+			if err := st.moveLiveRecords(oldest); err != nil {
+				return err
+			}
+		}
+
+		if err := oldest.file.Close(); err != nil {
+			return err
+		}
+		if err := os.Remove(oldest.file.Name()); err != nil {
+			return err
+		}
+		st.segments = st.segments[1:]
+
+		st.logger.Debug("segment removed", zap.Uint64("segment", oldest.id))
+	}
+
+	return nil
+}
+
+// moveLiveRecords copies the live records from the given segment to the active segment.
+// The caller is expected to hold the lock.
+func (st *diskStorage) moveLiveRecords(seg *segment) error {
+	for key, locs := range st.index {
+		for i, loc := range locs {
+			if loc.segment != seg.id {
+				continue
+			}
+
+			buf := make([]byte, loc.size)
+			if _, err := seg.file.ReadAt(buf, loc.offset); err != nil {
+				return err
+			}
+
+			newLoc, err := st.append(buf)
+			if err != nil {
+				return err
+			}
+			locs[i] = newLoc
+			seg.live--
+		}
+		st.index[key] = locs
+	}
+
+	return nil
+}
+
+// append writes the given record to the active segment, rotating it first if it's full.
+// The caller is expected to hold the lock.
+func (st *diskStorage) append(record []byte) (recordLocation, error) {
+	if st.closed || len(st.segments) == 0 {
+		return recordLocation{}, errStorageClosed
+	}
+
+	active := st.segments[len(st.segments)-1]
+	if active.size > 0 && active.size+int64(len(record)) > st.maxSegmentSize {
+		if err := st.rotate(); err != nil {
+			return recordLocation{}, err
+		}
+		active = st.segments[len(st.segments)-1]
+	}
+
+	if _, err := active.file.WriteAt(record, active.size); err != nil {
+		return recordLocation{}, fmt.Errorf("couldn't write to segment %d: %w", active.id, err)
+	}
+
+	loc := recordLocation{
+		segment:  active.id,
+		offset:   active.size,
+		size:     int64(len(record)),
+		checksum: binary.BigEndian.Uint32(record[len(record)-recordTrailerSize:]),
+	}
+	active.size += loc.size
+
+	if record[0] == recordTypeSpans {
+		active.live++
+		active.total++
+	}
+
+	return loc, nil
+}
+
+// rotate creates a new segment, which becomes the active one.
+// The caller is expected to hold the lock.
+func (st *diskStorage) rotate() error {
+	var id uint64
+	if len(st.segments) > 0 {
+		id = st.segments[len(st.segments)-1].id + 1
+	}
+
+	seg, err := st.openSegment(id)
+	if err != nil {
+		return err
+	}
+	st.segments = append(st.segments, seg)
+	return nil
+}
+
+func (st *diskStorage) openSegment(id uint64) (*segment, error) {
+	name := filepath.Join(st.directory, fmt.Sprintf("%s%020d%s", segmentFilePrefix, id, segmentFileSuffix))
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open segment %q: %w", name, err)
+	}
+
+	return &segment{
+		id:   id,
+		file: file,
+	}, nil
+}
+
+// load replays all the existing segments, from the oldest to the newest, rebuilding the index.
+// The caller is expected to hold the lock.
+func (st *diskStorage) load() error {
+	entries, err := ioutil.ReadDir(st.directory)
+	if err != nil {
+		return err
+	}
+
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, segmentFilePrefix) || !strings.HasSuffix(name, segmentFileSuffix) {
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentFilePrefix), segmentFileSuffix), 10, 64)
+		if err != nil {
+			st.logger.Warn("skipping unknown file in the storage directory", zap.String("file", name))
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		seg, err := st.openSegment(id)
+		if err != nil {
+			return err
+		}
+		st.segments = append(st.segments, seg)
+
+		if err := st.replay(seg); err != nil {
+			return fmt.Errorf("couldn't replay segment %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// replay reads all the records from the given segment, applying them to the index. A record that can't
+// be read, typically because it was only partially written when the collector stopped, is discarded along
+// with everything after it.
+// The caller is expected to hold the lock.
+func (st *diskStorage) replay(seg *segment) error {
+	info, err := seg.file.Stat()
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(seg.file)
+
+	var offset int64
+	for {
+		typ, key, size, checksum, err := readRecord(reader, info.Size()-offset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			st.logger.Warn("discarding the remaining of the segment", zap.Uint64("segment", seg.id), zap.Int64("offset", offset), zap.Error(err))
+			if err := seg.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+
+		switch typ {
+		case recordTypeSpans:
+			st.replayRecord(seg, key, recordLocation{
+				segment:  seg.id,
+				offset:   offset,
+				size:     size,
+				checksum: checksum,
+			})
+		case recordTypeTombstone:
+			st.removeFromIndex(key)
+		}
+
+		offset += size
+	}
+
+	seg.size = offset
+	return nil
+}
+
+// replayRecord adds the record to the index. A record identical to a record of the same trace in an older
+// segment is a copy made by a compaction that didn't get to remove the older segment: the copy replaces
+// the original, so that the spans aren't recovered twice.
+// The caller is expected to hold the lock.
+func (st *diskStorage) replayRecord(seg *segment, key [16]byte, loc recordLocation) {
+	locs := st.index[key]
+	replaced := false
+	for i, existing := range locs {
+		if existing.segment == loc.segment || existing.checksum != loc.checksum || existing.size != loc.size {
+			continue
+		}
+		if old := st.segment(existing.segment); old != nil {
+			old.live--
+		}
+		locs[i] = loc
+		replaced = true
+		break
+	}
+	if !replaced {
+		locs = append(locs, loc)
+	}
+	st.index[key] = locs
+
+	seg.live++
+	seg.total++
+}
+
+// removeFromIndex removes the trace from the index, updating the number of live records for the affected segments.
+// The caller is expected to hold the lock.
+func (st *diskStorage) removeFromIndex(key [16]byte) {
+	for _, loc := range st.index[key] {
+		if seg := st.segment(loc.segment); seg != nil {
+			seg.live--
+		}
+	}
+	delete(st.index, key)
+}
+
+func (st *diskStorage) segment(id uint64) *segment {
+	for _, seg := range st.segments {
+		if seg.id == id {
+			return seg
+		}
+	}
+	return nil
+}
+
+// read retrieves the resource spans from the given records.
+// The caller is expected to hold the lock.
+func (st *diskStorage) read(locs []recordLocation) ([]pdata.ResourceSpans, error) {
+	result := []pdata.ResourceSpans{}
+	for _, loc := range locs {
+		seg := st.segment(loc.segment)
+		if seg == nil {
+			return nil, fmt.Errorf("segment %d not found", loc.segment)
+		}
+
+		buf := make([]byte, loc.size)
+		if _, err := seg.file.ReadAt(buf, loc.offset); err != nil {
+			return nil, fmt.Errorf("couldn't read from segment %d: %w", loc.segment, err)
+		}
+
+		payload, err := decodeRecord(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		rss, err := unmarshalResourceSpans(payload)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't deserialize the resource spans: %w", err)
+		}
+		result = append(result, rss...)
+	}
+
+	return result, nil
+}
+
+// encodeRecord builds a record in the format: type (1 byte), trace ID (16 bytes), payload length (4 bytes),
+// payload and the CRC32 checksum of everything before it (4 bytes)
+func encodeRecord(typ byte, key [16]byte, payload []byte) []byte {
+	record := make([]byte, recordHeaderSize+len(payload)+recordTrailerSize)
+	record[0] = typ
+	copy(record[1:17], key[:])
+	binary.BigEndian.PutUint32(record[17:recordHeaderSize], uint32(len(payload)))
+	copy(record[recordHeaderSize:], payload)
+
+	checksum := crc32.ChecksumIEEE(record[:recordHeaderSize+len(payload)])
+	binary.BigEndian.PutUint32(record[recordHeaderSize+len(payload):], checksum)
+	return record
+}
+
+// decodeRecord validates the given record, returning its payload
+func decodeRecord(record []byte) ([]byte, error) {
+	if len(record) < recordHeaderSize+recordTrailerSize {
+		return nil, errStorageCorruptedRecord
+	}
+
+	payloadSize := int(binary.BigEndian.Uint32(record[17:recordHeaderSize]))
+	if len(record) != recordHeaderSize+payloadSize+recordTrailerSize {
+		return nil, errStorageCorruptedRecord
+	}
+
+	end := recordHeaderSize + payloadSize
+	if crc32.ChecksumIEEE(record[:end]) != binary.BigEndian.Uint32(record[end:]) {
+		return nil, errStorageCorruptedRecord
+	}
+
+	return record[recordHeaderSize:end], nil
+}
+
+// readRecord reads the next record from the reader, returning its type, trace ID, total size and checksum.
+// The remaining argument is the number of bytes left to be read, used to detect corrupted payload lengths.
+// It returns io.EOF only when there are no more records to read.
+func readRecord(reader io.Reader, remaining int64) (byte, [16]byte, int64, uint32, error) {
+	var key [16]byte
+
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errStorageCorruptedRecord
+		}
+		return 0, key, 0, 0, err
+	}
+
+	payloadSize := int(binary.BigEndian.Uint32(header[17:recordHeaderSize]))
+	if int64(recordHeaderSize+payloadSize+recordTrailerSize) > remaining {
+		return 0, key, 0, 0, errStorageCorruptedRecord
+	}
+
+	record := make([]byte, recordHeaderSize+payloadSize+recordTrailerSize)
+	copy(record, header)
+	if _, err := io.ReadFull(reader, record[recordHeaderSize:]); err != nil {
+		return 0, key, 0, 0, errStorageCorruptedRecord
+	}
+
+	if _, err := decodeRecord(record); err != nil {
+		return 0, key, 0, 0, err
+	}
+
+	typ := record[0]
+	if typ != recordTypeSpans && typ != recordTypeTombstone {
+		return 0, key, 0, 0, errStorageCorruptedRecord
+	}
+
+	copy(key[:], record[1:17])
+	checksum := binary.BigEndian.Uint32(record[len(record)-recordTrailerSize:])
+	return typ, key, int64(len(record)), checksum, nil
+}
+
+func marshalResourceSpans(rs pdata.ResourceSpans) ([]byte, error) {
+	newRS := pdata.NewResourceSpans()
+	rs.CopyTo(newRS)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Append(newRS)
+	return td.ToOtlpProtoBytes()
+}
+
+func unmarshalResourceSpans(payload []byte) ([]pdata.ResourceSpans, error) {
+	td := pdata.NewTraces()
+	if err := td.FromOtlpProtoBytes(payload); err != nil {
+		return nil, err
+	}
+
+	result := make([]pdata.ResourceSpans, 0, td.ResourceSpans().Len())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		result = append(result, td.ResourceSpans().At(i))
+	}
+	return result, nil
+}
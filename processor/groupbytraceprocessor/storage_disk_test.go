@@ -0,0 +1,355 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1, 2, 3, 4}),
+		pdata.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "first")))
+		require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "second")))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 2)
+		assertResourceSpans(t, traceID, "first", retrieved[0])
+		assertResourceSpans(t, traceID, "second", retrieved[1])
+	}
+}
+
+func TestDiskGetNonExistingTrace(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	// test
+	retrieved, err := st.get(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+
+	// verify
+	assert.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskCreateWithNilResourceSpans(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	// test
+	err := st.createOrAppend(pdata.NewTraceID([16]byte{1, 2, 3, 4}), pdata.NewResourceSpans())
+
+	// verify
+	assert.Equal(t, errStorageNilResourceSpans, err)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "first")))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assertResourceSpans(t, traceID, "first", deleted[0])
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	assert.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskOperationsAfterShutdown(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "first")))
+	require.NoError(t, st.shutdown())
+
+	// test and verify
+	assert.Equal(t, errStorageClosed, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "second")))
+
+	retrieved, err := st.get(traceID)
+	assert.Equal(t, errStorageClosed, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err := st.delete(traceID)
+	assert.Equal(t, errStorageClosed, err)
+	assert.Nil(t, deleted)
+}
+
+func TestDiskRecoverAfterRestart(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+
+	kept := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	removed := pdata.NewTraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(kept, resourceSpansWithTraceID(kept, "first")))
+	require.NoError(t, st.createOrAppend(removed, resourceSpansWithTraceID(removed, "first")))
+	require.NoError(t, st.createOrAppend(kept, resourceSpansWithTraceID(kept, "second")))
+	_, err := st.delete(removed)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	restarted := newDiskStorage(zap.NewNop(), dir)
+	require.NoError(t, restarted.start())
+	defer restarted.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{kept}, restarted.traceIDs())
+
+	retrieved, err := restarted.get(kept)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assertResourceSpans(t, kept, "first", retrieved[0])
+	assertResourceSpans(t, kept, "second", retrieved[1])
+}
+
+func TestDiskDiscardsPartiallyWrittenRecord(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "first")))
+	segmentName := st.segments[len(st.segments)-1].file.Name()
+	require.NoError(t, st.shutdown())
+
+	// simulate a crash while writing the second record
+	record := encodeRecord(recordTypeSpans, pdata.NewTraceID([16]byte{2, 3, 4, 5}).Bytes(), []byte("some payload"))
+	f, err := os.OpenFile(segmentName, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(record[:len(record)-2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// test
+	restarted := newDiskStorage(zap.NewNop(), dir)
+	require.NoError(t, restarted.start())
+	defer restarted.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{traceID}, restarted.traceIDs())
+	info, err := os.Stat(segmentName)
+	require.NoError(t, err)
+	assert.Equal(t, restarted.segments[0].size, info.Size())
+}
+
+func TestDiskCompactionRemovesDeadSegments(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	st.maxSegmentSize = 1 // each record goes to its own segment
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	first := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	second := pdata.NewTraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(first, resourceSpansWithTraceID(first, "first")))
+	require.NoError(t, st.createOrAppend(second, resourceSpansWithTraceID(second, "first")))
+	_, err := st.delete(first)
+	require.NoError(t, err)
+	require.Len(t, st.segments, 3)
+
+	// test
+	require.NoError(t, st.compact())
+
+	// verify
+	// the segment for the first trace is gone, but the second trace is still live
+	assert.Len(t, st.segments, 2)
+	assert.Len(t, segmentFiles(t, dir), 2)
+
+	retrieved, err := st.get(second)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestDiskCompactionMovesLiveRecords(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	st.compactionThreshold = 0.5
+	require.NoError(t, st.start())
+	defer st.shutdown()
+
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1, 2, 3, 4}),
+		pdata.NewTraceID([16]byte{2, 3, 4, 5}),
+		pdata.NewTraceID([16]byte{3, 4, 5, 6}),
+	}
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, resourceSpansWithTraceID(traceID, "first")))
+	}
+	for _, traceID := range traceIDs[:2] {
+		_, err := st.delete(traceID)
+		require.NoError(t, err)
+	}
+	require.NoError(t, st.rotate())
+	require.Len(t, st.segments, 2)
+
+	// test
+	require.NoError(t, st.compact())
+
+	// verify
+	assert.Len(t, st.segments, 1)
+	assert.Len(t, segmentFiles(t, dir), 1)
+
+	retrieved, err := st.get(traceIDs[2])
+	require.NoError(t, err)
+	require.Len(t, retrieved, 1)
+	assertResourceSpans(t, traceIDs[2], "first", retrieved[0])
+}
+
+func TestDiskRecoverAfterCrashDuringCompaction(t *testing.T) {
+	// prepare
+	st, dir := newTestDiskStorage(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, st.start())
+
+	kept := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	removed := pdata.NewTraceID([16]byte{2, 3, 4, 5})
+	require.NoError(t, st.createOrAppend(kept, resourceSpansWithTraceID(kept, "first")))
+	require.NoError(t, st.createOrAppend(removed, resourceSpansWithTraceID(removed, "first")))
+	_, err := st.delete(removed)
+	require.NoError(t, err)
+	require.NoError(t, st.rotate())
+	require.NoError(t, st.createOrAppend(kept, resourceSpansWithTraceID(kept, "second")))
+
+	// simulate a crash after the live records are moved, but before the oldest segment is removed
+	st.Lock()
+	require.NoError(t, st.moveLiveRecords(st.segments[0]))
+	st.Unlock()
+	require.NoError(t, st.shutdown())
+	require.Len(t, segmentFiles(t, dir), 2)
+
+	// test
+	restarted := newDiskStorage(zap.NewNop(), dir)
+	require.NoError(t, restarted.start())
+	defer restarted.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{kept}, restarted.traceIDs())
+	retrieved, err := restarted.get(kept)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assertResourceSpans(t, kept, "first", retrieved[0])
+	assertResourceSpans(t, kept, "second", retrieved[1])
+
+	// the oldest segment has no live records left, and goes away with the next compaction
+	require.NoError(t, restarted.compact())
+	assert.Len(t, segmentFiles(t, dir), 2)
+}
+
+func TestDiskRecordRoundTrip(t *testing.T) {
+	// prepare
+	key := pdata.NewTraceID([16]byte{1, 2, 3, 4}).Bytes()
+	record := encodeRecord(recordTypeSpans, key, []byte("some payload"))
+
+	// test
+	payload, err := decodeRecord(record)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []byte("some payload"), payload)
+
+	// a corrupted record is detected
+	record[recordHeaderSize] = 'S'
+	_, err = decodeRecord(record)
+	assert.Equal(t, errStorageCorruptedRecord, err)
+}
+
+func newTestDiskStorage(t *testing.T) (*diskStorage, string) {
+	dir, err := ioutil.TempDir("", "groupbytrace")
+	require.NoError(t, err)
+	return newDiskStorage(zap.NewNop(), dir), dir
+}
+
+func resourceSpansWithTraceID(traceID pdata.TraceID, name string) pdata.ResourceSpans {
+	rs := pdata.NewResourceSpans()
+	rs.InitEmpty()
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "some-service")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	ils.Spans().Resize(1)
+	span := ils.Spans().At(0)
+	span.SetTraceID(traceID)
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4}))
+	span.SetName(name)
+	return rs
+}
+
+// assertResourceSpans checks that the given resource spans has been built by resourceSpansWithTraceID with the given arguments
+func assertResourceSpans(t *testing.T, traceID pdata.TraceID, name string, rs pdata.ResourceSpans) {
+	serviceName, found := rs.Resource().Attributes().Get("service.name")
+	require.True(t, found)
+	assert.Equal(t, "some-service", serviceName.StringVal())
+
+	require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+	require.Equal(t, 1, rs.InstrumentationLibrarySpans().At(0).Spans().Len())
+	span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, traceID, span.TraceID())
+	assert.Equal(t, name, span.Name())
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, segmentFilePrefix+"*"+segmentFileSuffix))
+	require.NoError(t, err)
+	return files
+}
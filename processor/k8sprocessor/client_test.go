@@ -28,11 +28,14 @@ import (
 
 // fakeClient is used as a replacement for WatchClient in test cases.
 type fakeClient struct {
-	Pods     map[string]*kube.Pod
-	Rules    kube.ExtractionRules
-	Filters  kube.Filters
-	Informer cache.SharedInformer
-	StopCh   chan struct{}
+	Pods              map[string]*kube.Pod
+	PodsByUID         map[string]*kube.Pod
+	PodsByName        map[string]*kube.Pod
+	PodsByContainerID map[string]*kube.Pod
+	Rules             kube.ExtractionRules
+	Filters           kube.Filters
+	Informer          cache.SharedInformer
+	StopCh            chan struct{}
 }
 
 func selectors() (labels.Selector, fields.Selector) {
@@ -49,11 +52,14 @@ func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.Extract
 
 	ls, fs := selectors()
 	return &fakeClient{
-		Pods:     map[string]*kube.Pod{},
-		Rules:    rules,
-		Filters:  filters,
-		Informer: kube.NewFakeInformer(cs, "", ls, fs),
-		StopCh:   make(chan struct{}),
+		Pods:              map[string]*kube.Pod{},
+		PodsByUID:         map[string]*kube.Pod{},
+		PodsByName:        map[string]*kube.Pod{},
+		PodsByContainerID: map[string]*kube.Pod{},
+		Rules:             rules,
+		Filters:           filters,
+		Informer:          kube.NewFakeInformer(cs, "", ls, fs),
+		StopCh:            make(chan struct{}),
 	}, nil
 }
 
@@ -63,6 +69,24 @@ func (f *fakeClient) GetPodByIP(ip string) (*kube.Pod, bool) {
 	return p, ok
 }
 
+// GetPodByUID looks up FakeClient.PodsByUID map by the provided string.
+func (f *fakeClient) GetPodByUID(uid string) (*kube.Pod, bool) {
+	p, ok := f.PodsByUID[uid]
+	return p, ok
+}
+
+// GetPodByName looks up FakeClient.PodsByName map by "<namespace>/<name>".
+func (f *fakeClient) GetPodByName(namespace, name string) (*kube.Pod, bool) {
+	p, ok := f.PodsByName[namespace+"/"+name]
+	return p, ok
+}
+
+// GetPodByContainerID looks up FakeClient.PodsByContainerID map by the provided string.
+func (f *fakeClient) GetPodByContainerID(containerID string) (*kube.Pod, bool) {
+	p, ok := f.PodsByContainerID[containerID]
+	return p, ok
+}
+
 // Start is a noop for FakeClient.
 func (f *fakeClient) Start() {
 	if f.Informer != nil {
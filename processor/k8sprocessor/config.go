@@ -39,6 +39,11 @@ type Config struct {
 	// Filter section allows specifying filters to filter
 	// pods by labels, fields, namespaces, nodes, etc.
 	Filter FilterConfig `mapstructure:"filter"`
+
+	// Association section allows specifying rules to associate data with
+	// pods. The rules are evaluated in order, the first one matching a pod wins.
+	// When no rules are specified, data is associated with pods by IP address.
+	Association []PodAssociationConfig `mapstructure:"pod_association"`
 }
 
 // ExtractConfig section allows specifying extraction rules to extract
@@ -65,6 +70,28 @@ type ExtractConfig struct {
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// NamespaceAnnotations allows extracting data from the annotations of the
+	// namespace of the pod. Default tag names are k8s.namespace.annotations.<annotation key>.
+	// Enabling it makes the processor watch namespaces.
+	NamespaceAnnotations []FieldExtractConfig `mapstructure:"namespace_annotations"`
+
+	// NamespaceLabels allows extracting data from the labels of the namespace
+	// of the pod. Default tag names are k8s.namespace.labels.<label key>.
+	// Enabling it makes the processor watch namespaces.
+	NamespaceLabels []FieldExtractConfig `mapstructure:"namespace_labels"`
+
+	// NodeLabels allows extracting data from the labels of the node the pod
+	// is running on, such as topology.kubernetes.io/zone. Default tag names are
+	// k8s.node.labels.<label key>. Enabling it makes the processor watch nodes.
+	NodeLabels []FieldExtractConfig `mapstructure:"node_labels"`
+
+	// OwnerLookup enables resolving the owners of pods up the chain, ReplicaSet
+	// to Deployment and Job to CronJob, and adds their names as k8s.replicaset.name,
+	// k8s.deployment.name, k8s.daemonset.name, k8s.statefulset.name, k8s.job.name
+	// and k8s.cronjob.name. The deployment name is then no longer guessed from
+	// the pod name. Enabling it makes the processor watch ReplicaSets and Jobs.
+	OwnerLookup bool `mapstructure:"owner_lookup"`
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a value from exactly one field.
@@ -173,3 +200,16 @@ type FieldFilterConfig struct {
 	//   equals, not-equals, exists, does-not-exist.
 	Op string `mapstructure:"op"`
 }
+
+// PodAssociationConfig allows specifying one rule to associate data with a pod.
+type PodAssociationConfig struct {
+	// From represents the source of the association.
+	// Allowed values are "resource_attribute" and "connection".
+	From string `mapstructure:"from"`
+
+	// Name represents the resource attribute holding the value to look
+	// pods up with, when From is "resource_attribute". Pods are looked up by
+	// UID for k8s.pod.uid, by name and k8s.namespace.name for k8s.pod.name,
+	// by container ID for container.id, and by IP address for any other attribute.
+	Name string `mapstructure:"name"`
+}
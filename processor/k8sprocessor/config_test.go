@@ -75,6 +75,13 @@ func TestLoadConfig(t *testing.T) {
 					{TagName: "l1", Key: "label1"},
 					{TagName: "l2", Key: "label2", Regex: "field=(?P<value>.+)"},
 				},
+				NamespaceLabels: []FieldExtractConfig{
+					{Key: "team"},
+				},
+				NodeLabels: []FieldExtractConfig{
+					{TagName: "cloud.zone", Key: "topology.kubernetes.io/zone"},
+				},
+				OwnerLookup: true,
 			},
 			Filter: FilterConfig{
 				Namespace:      "ns2",
@@ -89,5 +96,9 @@ func TestLoadConfig(t *testing.T) {
 					{Key: "key2", Value: "value2", Op: "not-equals"},
 				},
 			},
+			Association: []PodAssociationConfig{
+				{From: "resource_attribute", Name: "k8s.pod.uid"},
+				{From: "connection"},
+			},
 		})
 }
@@ -27,10 +27,52 @@
 //
 // TODO: mention the required RBAC rules.
 //
+// Metadata from other resources
+//
+// Besides pods, the processor can add metadata from related resources. Each of them is watched only when
+// configured, to keep the load on the API server down, and requires the processor to be allowed to list and
+// watch the corresponding resources:
+//
+//    k8s_tagger:
+//      extract:
+//        namespace_labels:      # requires namespaces
+//          - key: team
+//        namespace_annotations: # requires namespaces
+//          - key: owner
+//        node_labels:           # requires nodes
+//          - tag_name: cloud.zone
+//            key: topology.kubernetes.io/zone
+//          - tag_name: host.type
+//            key: node.kubernetes.io/instance-type
+//        owner_lookup: true     # requires replicasets and jobs
+//
+// With `owner_lookup`, owner references are resolved up the chain, from ReplicaSet to Deployment and from Job
+// to CronJob, and added as k8s.replicaset.name, k8s.deployment.name, k8s.daemonset.name, k8s.statefulset.name,
+// k8s.job.name and k8s.cronjob.name. Metadata of related resources is read when pods are added or updated.
+//
 // Config
 //
 // TODO: example config.
 //
+// Pod association
+//
+// The way data is associated with pods can be configured with the `pod_association` option, a list of rules
+// evaluated in order. The first rule matching a pod wins. A rule either reads a resource attribute
+// (`from: resource_attribute`) or uses the IP address of the connection the data was received on (`from: connection`).
+// Resource attributes are matched against the pod UID for "k8s.pod.uid", the pod name and the "k8s.namespace.name"
+// attribute for "k8s.pod.name", the IDs of the pod containers for "container.id" and the pod IP address for any other
+// attribute.
+//
+//    k8s_tagger:
+//      pod_association:
+//        - from: resource_attribute
+//          name: k8s.pod.uid
+//        - from: resource_attribute
+//          name: container.id
+//        - from: connection
+//
+// When no rules are configured, the processor associates data with pods by IP address as described above.
+//
 // Deployment scenarios
 //
 // The processor supports running both in agent and collector mode.
@@ -105,9 +147,10 @@
 //
 // Host networking mode
 //
-// The processor cannot correct identify pods running in the host network mode and
+// The processor cannot correct identify pods running in the host network mode by IP address and
 // enriching telemetry data generated by such pods is not supported at the moment, unless the attributes contain
-// information about the source IP.
+// information about the source IP, or the pod can be associated by UID, name or container ID with
+// the `pod_association` option.
 //
 // As a sidecar
 //
@@ -182,6 +182,12 @@ func createProcessorOpts(cfg configmodels.Processor) []Option {
 	opts = append(opts, WithExtractMetadata(oCfg.Extract.Metadata...))
 	opts = append(opts, WithExtractLabels(oCfg.Extract.Labels...))
 	opts = append(opts, WithExtractAnnotations(oCfg.Extract.Annotations...))
+	opts = append(opts, WithExtractNamespaceLabels(oCfg.Extract.NamespaceLabels...))
+	opts = append(opts, WithExtractNamespaceAnnotations(oCfg.Extract.NamespaceAnnotations...))
+	opts = append(opts, WithExtractNodeLabels(oCfg.Extract.NodeLabels...))
+	if oCfg.Extract.OwnerLookup {
+		opts = append(opts, WithOwnerLookup())
+	}
 
 	// filters
 	opts = append(opts, WithFilterNode(oCfg.Filter.Node, oCfg.Filter.NodeFromEnvVar))
@@ -190,5 +196,8 @@ func createProcessorOpts(cfg configmodels.Processor) []Option {
 	opts = append(opts, WithFilterFields(oCfg.Filter.Fields...))
 	opts = append(opts, WithAPIConfig(oCfg.APIConfig))
 
+	// pod association rules
+	opts = append(opts, WithPodAssociations(oCfg.Association...))
+
 	return opts
 }
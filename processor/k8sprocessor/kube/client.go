@@ -23,6 +23,8 @@ import (
 
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -45,9 +47,22 @@ type WatchClient struct {
 	deleteQueue     []deleteRequest
 	stopCh          chan struct{}
 
-	Pods    map[string]*Pod
-	Rules   ExtractionRules
-	Filters Filters
+	// The following informers are only set when the extraction rules need them.
+	namespaceInformer  cache.SharedInformer
+	nodeInformer       cache.SharedInformer
+	replicaSetInformer cache.SharedInformer
+	jobInformer        cache.SharedInformer
+
+	// Pods holds the pods by IP address.
+	Pods map[string]*Pod
+	// PodsByUID holds the pods by UID.
+	PodsByUID map[string]*Pod
+	// PodsByName holds the pods by "<namespace>/<name>".
+	PodsByName map[string]*Pod
+	// PodsByContainerID holds the pods by the IDs of their containers.
+	PodsByContainerID map[string]*Pod
+	Rules             ExtractionRules
+	Filters           Filters
 }
 
 // Extract deployment name from the pod name. Pod name is created using
@@ -60,6 +75,9 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
 
 	c.Pods = map[string]*Pod{}
+	c.PodsByUID = map[string]*Pod{}
+	c.PodsByName = map[string]*Pod{}
+	c.PodsByContainerID = map[string]*Pod{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -84,11 +102,33 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	}
 
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+
+	if len(c.Rules.NamespaceLabels) > 0 || len(c.Rules.NamespaceAnnotations) > 0 {
+		c.namespaceInformer = newNamespaceSharedInformer(c.kc, c.Filters.Namespace)
+	}
+	if len(c.Rules.NodeLabels) > 0 {
+		c.nodeInformer = newNodeSharedInformer(c.kc, c.Filters.Node)
+	}
+	if c.Rules.Owners {
+		c.replicaSetInformer = newReplicaSetSharedInformer(c.kc, c.Filters.Namespace)
+		c.jobInformer = newJobSharedInformer(c.kc, c.Filters.Namespace)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
 func (c *WatchClient) Start() {
+	var synced []cache.InformerSynced
+	for _, informer := range c.metadataInformers() {
+		go informer.Run(c.stopCh)
+		synced = append(synced, informer.HasSynced)
+	}
+	// Pod attributes are extracted when pods are added or updated, wait for the
+	// metadata caches to be filled so that the first pods get all of them.
+	if !cache.WaitForCacheSync(c.stopCh, synced...) {
+		return
+	}
+
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -97,6 +137,17 @@ func (c *WatchClient) Start() {
 	c.informer.Run(c.stopCh)
 }
 
+// metadataInformers returns the informers on the resources pods are enriched with.
+func (c *WatchClient) metadataInformers() []cache.SharedInformer {
+	var informers []cache.SharedInformer
+	for _, informer := range []cache.SharedInformer{c.namespaceInformer, c.nodeInformer, c.replicaSetInformer, c.jobInformer} {
+		if informer != nil {
+			informers = append(informers, informer)
+		}
+	}
+	return informers
+}
+
 // Stop signals the the k8s watcher/informer to stop watching for new events.
 func (c *WatchClient) Stop() {
 	close(c.stopCh)
@@ -152,13 +203,7 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 
 			c.m.Lock()
 			for _, d := range toDelete {
-				if p, ok := c.Pods[d.ip]; ok {
-					// Sanity check: make sure we are deleting the same pod
-					// and the underlying state (ip<>pod mapping) has not changed.
-					if p.Name == d.name {
-						delete(c.Pods, d.ip)
-					}
-				}
+				c.deletePod(d)
 			}
 			c.m.Unlock()
 
@@ -183,6 +228,53 @@ func (c *WatchClient) GetPodByIP(ip string) (*Pod, bool) {
 	return nil, false
 }
 
+// GetPodByUID takes a pod UID and returns the pod with this UID.
+func (c *WatchClient) GetPodByUID(uid string) (*Pod, bool) {
+	return c.getPod(c.PodsByUID, uid)
+}
+
+// GetPodByName takes a namespace and a pod name and returns the pod with this name in the namespace.
+func (c *WatchClient) GetPodByName(namespace, name string) (*Pod, bool) {
+	return c.getPod(c.PodsByName, podNameKey(namespace, name))
+}
+
+// GetPodByContainerID takes a container ID and returns the pod the container is running in.
+func (c *WatchClient) GetPodByContainerID(containerID string) (*Pod, bool) {
+	return c.getPod(c.PodsByContainerID, containerID)
+}
+
+func (c *WatchClient) getPod(index map[string]*Pod, key string) (*Pod, bool) {
+	c.m.RLock()
+	pod, ok := index[key]
+	c.m.RUnlock()
+	if !ok || pod.Excluded {
+		return nil, false
+	}
+	return pod, true
+}
+
+func podNameKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// containerIDs returns the IDs of the containers of the pod, without the
+// container runtime prefix (ie docker://).
+func containerIDs(pod *api_v1.Pod) []string {
+	var ids []string
+	for _, statuses := range [][]api_v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			id := status.ContainerID
+			if i := strings.Index(id, "://"); i != -1 {
+				id = id[i+3:]
+			}
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
 func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	tags := map[string]string{}
 	if c.Rules.PodName {
@@ -205,7 +297,9 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		tags[conventions.AttributeK8sPodUID] = string(uid)
 	}
 
-	if c.Rules.Deployment {
+	if c.Rules.Owners {
+		c.extractOwnerAttributes(pod, tags)
+	} else if c.Rules.Deployment {
 		// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
 		parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
 		if len(parts) == 2 {
@@ -224,20 +318,77 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		}
 	}
 
-	for _, r := range c.Rules.Labels {
-		if v, ok := pod.Labels[r.Key]; ok {
-			tags[r.Name] = c.extractField(v, r)
+	c.extractFields(tags, pod.Labels, c.Rules.Labels)
+	c.extractFields(tags, pod.Annotations, c.Rules.Annotations)
+
+	if c.namespaceInformer != nil {
+		if ns, ok := getFromStore(c.namespaceInformer, pod.Namespace).(*api_v1.Namespace); ok {
+			c.extractFields(tags, ns.Labels, c.Rules.NamespaceLabels)
+			c.extractFields(tags, ns.Annotations, c.Rules.NamespaceAnnotations)
 		}
 	}
 
-	for _, r := range c.Rules.Annotations {
-		if v, ok := pod.Annotations[r.Key]; ok {
-			tags[r.Name] = c.extractField(v, r)
+	if c.nodeInformer != nil {
+		if node, ok := getFromStore(c.nodeInformer, pod.Spec.NodeName).(*api_v1.Node); ok {
+			c.extractFields(tags, node.Labels, c.Rules.NodeLabels)
 		}
 	}
 	return tags
 }
 
+// extractOwnerAttributes adds the names of the pod owners to the tags, following
+// the owner references of ReplicaSets and Jobs up to their Deployment and CronJob.
+func (c *WatchClient) extractOwnerAttributes(pod *api_v1.Pod, tags map[string]string) {
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case "ReplicaSet":
+			tags[conventions.AttributeK8sReplicaSet] = ref.Name
+			rs, ok := getFromStore(c.replicaSetInformer, pod.Namespace+"/"+ref.Name).(*apps_v1.ReplicaSet)
+			if !ok {
+				continue
+			}
+			for _, rsRef := range rs.OwnerReferences {
+				if rsRef.Kind == "Deployment" {
+					tags[conventions.AttributeK8sDeployment] = rsRef.Name
+				}
+			}
+		case "DaemonSet":
+			tags[conventions.AttributeK8sDaemonSet] = ref.Name
+		case "StatefulSet":
+			tags[conventions.AttributeK8sStatefulSet] = ref.Name
+		case "Job":
+			tags[conventions.AttributeK8sJob] = ref.Name
+			job, ok := getFromStore(c.jobInformer, pod.Namespace+"/"+ref.Name).(*batch_v1.Job)
+			if !ok {
+				continue
+			}
+			for _, jobRef := range job.OwnerReferences {
+				if jobRef.Kind == "CronJob" {
+					tags[conventions.AttributeK8sCronJob] = jobRef.Name
+				}
+			}
+		}
+	}
+}
+
+func (c *WatchClient) extractFields(tags map[string]string, values map[string]string, rules []FieldExtractionRule) {
+	for _, r := range rules {
+		if v, ok := values[r.Key]; ok {
+			tags[r.Name] = c.extractField(v, r)
+		}
+	}
+}
+
+// getFromStore returns the object with the given key from the informer cache,
+// or nil if it is not there.
+func getFromStore(informer cache.SharedInformer, key string) interface{} {
+	obj, exists, err := informer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj
+}
+
 func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 	// Check if a subset of the field should be extracted with a regular expression
 	// instead of the whole field.
@@ -253,12 +404,45 @@ func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 }
 
 func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
-		return
+	newPod := &Pod{
+		Name:         pod.Name,
+		Namespace:    pod.Namespace,
+		UID:          string(pod.UID),
+		Address:      pod.Status.PodIP,
+		ContainerIDs: containerIDs(pod),
+		StartTime:    pod.Status.StartTime,
+	}
+
+	if c.shouldIgnorePod(pod) {
+		newPod.Ignore = true
+	}
+	if c.shouldExcludePod(pod) {
+		newPod.Excluded = true
+	} else {
+		newPod.Attributes = c.extractPodAttributes(pod)
 	}
 
 	c.m.Lock()
 	defer c.m.Unlock()
+
+	if newPod.UID != "" {
+		if p, ok := c.PodsByUID[newPod.UID]; ok {
+			// Containers restarted since the last update have new IDs.
+			for _, id := range p.ContainerIDs {
+				delete(c.PodsByContainerID, id)
+			}
+		}
+		c.PodsByUID[newPod.UID] = newPod
+		c.PodsByName[podNameKey(newPod.Namespace, newPod.Name)] = newPod
+		for _, id := range newPod.ContainerIDs {
+			c.PodsByContainerID[id] = newPod
+		}
+	}
+
+	if pod.Status.PodIP == "" {
+		return
+	}
+
 	// compare initial scheduled timestamp for existing pod and new pod with same IP
 	// and only replace old pod if scheduled time of new pod is newer? This should fix
 	// the case where scheduler has assigned the same IP to a new pod but update event for
@@ -268,36 +452,60 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 			return
 		}
 	}
-	newPod := &Pod{
-		Name:      pod.Name,
-		Address:   pod.Status.PodIP,
-		StartTime: pod.Status.StartTime,
-	}
-
-	if c.shouldIgnorePod(pod) {
-		newPod.Ignore = true
-	} else {
-		newPod.Attributes = c.extractPodAttributes(pod)
-	}
 	c.Pods[pod.Status.PodIP] = newPod
 }
 
 func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
-		return
-	}
 	c.m.RLock()
-	p, ok := c.GetPodByIP(pod.Status.PodIP)
+	p, ipFound := c.Pods[pod.Status.PodIP]
+	byUID, uidFound := c.PodsByUID[string(pod.UID)]
 	c.m.RUnlock()
 
-	if ok && p.Name == pod.Name {
-		c.deleteMut.Lock()
-		c.deleteQueue = append(c.deleteQueue, deleteRequest{
-			ip:   pod.Status.PodIP,
-			name: pod.Name,
-			ts:   time.Now(),
-		})
-		c.deleteMut.Unlock()
+	ipFound = ipFound && pod.Status.PodIP != "" && p.Name == pod.Name
+	if !ipFound && !uidFound {
+		return
+	}
+
+	d := deleteRequest{
+		name:      pod.Name,
+		namespace: pod.Namespace,
+		ts:        time.Now(),
+	}
+	if ipFound {
+		d.ip = pod.Status.PodIP
+	}
+	if uidFound {
+		d.uid = byUID.UID
+		d.containerIDs = byUID.ContainerIDs
+	}
+
+	c.deleteMut.Lock()
+	c.deleteQueue = append(c.deleteQueue, d)
+	c.deleteMut.Unlock()
+}
+
+// deletePod removes the pod of the delete request from the indexes still holding it.
+func (c *WatchClient) deletePod(d deleteRequest) {
+	if p, ok := c.Pods[d.ip]; ok && d.ip != "" {
+		// Sanity check: make sure we are deleting the same pod
+		// and the underlying state (ip<>pod mapping) has not changed.
+		if p.Name == d.name {
+			delete(c.Pods, d.ip)
+		}
+	}
+
+	if d.uid == "" {
+		return
+	}
+	delete(c.PodsByUID, d.uid)
+	nameKey := podNameKey(d.namespace, d.name)
+	if p, ok := c.PodsByName[nameKey]; ok && p.UID == d.uid {
+		delete(c.PodsByName, nameKey)
+	}
+	for _, id := range d.containerIDs {
+		if p, ok := c.PodsByContainerID[id]; ok && p.UID == d.uid {
+			delete(c.PodsByContainerID, id)
+		}
 	}
 }
 
@@ -311,6 +519,12 @@ func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
 		return true
 	}
 
+	return c.shouldExcludePod(pod)
+}
+
+// shouldExcludePod returns true if the pod must not be associated with any data,
+// whatever the association used.
+func (c *WatchClient) shouldExcludePod(pod *api_v1.Pod) bool {
 	// Check if user requested the pod to be ignored through annotations
 	if v, ok := pod.Annotations[ignoreAnnotation]; ok {
 		if strings.ToLower(strings.TrimSpace(v)) == "true" {
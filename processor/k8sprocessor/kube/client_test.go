@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -291,6 +293,103 @@ func TestGetIgnoredPod(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestPodIndexes(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	pod := &api_v1.Pod{}
+	pod.Name = "podA"
+	pod.Namespace = "ns1"
+	pod.UID = "uid-1"
+	pod.Spec.HostNetwork = true
+	pod.Status.PodIP = "1.1.1.1"
+	pod.Status.InitContainerStatuses = []api_v1.ContainerStatus{{ContainerID: "docker://init-1"}}
+	pod.Status.ContainerStatuses = []api_v1.ContainerStatus{{ContainerID: "containerd://c-1"}, {}}
+	c.handlePodAdd(pod)
+
+	// host network pods can't be associated by IP, but can be by any other key
+	_, ok := c.GetPodByIP("1.1.1.1")
+	assert.False(t, ok)
+
+	got, ok := c.GetPodByUID("uid-1")
+	require.True(t, ok)
+	assert.Equal(t, "podA", got.Name)
+	assert.Equal(t, "ns1", got.Namespace)
+	assert.Equal(t, []string{"init-1", "c-1"}, got.ContainerIDs)
+
+	got, ok = c.GetPodByName("ns1", "podA")
+	require.True(t, ok)
+	assert.Equal(t, "uid-1", got.UID)
+	_, ok = c.GetPodByName("ns2", "podA")
+	assert.False(t, ok)
+
+	got, ok = c.GetPodByContainerID("c-1")
+	require.True(t, ok)
+	assert.Equal(t, "uid-1", got.UID)
+	_, ok = c.GetPodByContainerID("init-1")
+	assert.True(t, ok)
+
+	// restarted containers replace the previous IDs
+	pod.Status.ContainerStatuses = []api_v1.ContainerStatus{{ContainerID: "containerd://c-2"}}
+	c.handlePodUpdate(pod, pod)
+	_, ok = c.GetPodByContainerID("c-1")
+	assert.False(t, ok)
+	_, ok = c.GetPodByContainerID("c-2")
+	assert.True(t, ok)
+
+	c.handlePodDelete(pod)
+	require.Equal(t, 1, len(c.deleteQueue))
+	d := c.deleteQueue[0]
+	assert.Equal(t, "1.1.1.1", d.ip)
+	assert.Equal(t, "uid-1", d.uid)
+	assert.Equal(t, []string{"init-1", "c-2"}, d.containerIDs)
+
+	c.deletePod(d)
+	assert.Equal(t, 0, len(c.Pods))
+	assert.Equal(t, 0, len(c.PodsByUID))
+	assert.Equal(t, 0, len(c.PodsByName))
+	assert.Equal(t, 0, len(c.PodsByContainerID))
+}
+
+func TestDeletePodKeepsNewerPod(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	pod := &api_v1.Pod{}
+	pod.Name = "podA"
+	pod.Namespace = "ns1"
+	pod.UID = "uid-1"
+	c.handlePodAdd(pod)
+	c.handlePodDelete(pod)
+	require.Equal(t, 1, len(c.deleteQueue))
+
+	// a pod with the same name is recreated before the grace period ends
+	newPod := pod.DeepCopy()
+	newPod.UID = "uid-2"
+	c.handlePodAdd(newPod)
+
+	c.deletePod(c.deleteQueue[0])
+	_, ok := c.GetPodByUID("uid-1")
+	assert.False(t, ok)
+	got, ok := c.GetPodByName("ns1", "podA")
+	require.True(t, ok)
+	assert.Equal(t, "uid-2", got.UID)
+}
+
+func TestGetExcludedPod(t *testing.T) {
+	c, _ := newTestClient(t)
+	pod := &api_v1.Pod{}
+	pod.Name = "podA"
+	pod.Namespace = "ns1"
+	pod.UID = "uid-1"
+	pod.Annotations = map[string]string{
+		"opentelemetry.io/k8s-processor/ignore": "true",
+	}
+	c.handlePodAdd(pod)
+	_, ok := c.GetPodByUID("uid-1")
+	assert.False(t, ok)
+	_, ok = c.GetPodByName("ns1", "podA")
+	assert.False(t, ok)
+}
+
 func TestHandlerWrongType(t *testing.T) {
 	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
 	assert.Equal(t, logs.Len(), 0)
@@ -407,6 +506,154 @@ func TestExtractionRules(t *testing.T) {
 	}
 }
 
+func TestOwnerExtraction(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{Owners: true, Deployment: true}, Filters{})
+	require.NotNil(t, c.replicaSetInformer)
+	require.NotNil(t, c.jobInformer)
+	assert.Nil(t, c.namespaceInformer)
+	assert.Nil(t, c.nodeInformer)
+
+	require.NoError(t, c.replicaSetInformer.GetStore().Add(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "auth-service-abc12",
+			Namespace:       "ns1",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Deployment", Name: "auth"}},
+		},
+	}))
+	require.NoError(t, c.jobInformer.GetStore().Add(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "backup-1604000000",
+			Namespace:       "ns1",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "CronJob", Name: "backup"}},
+		},
+	}))
+
+	testCases := []struct {
+		name       string
+		owner      meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name:  "deployment",
+		owner: meta_v1.OwnerReference{Kind: "ReplicaSet", Name: "auth-service-abc12"},
+		attributes: map[string]string{
+			"k8s.replicaset.name": "auth-service-abc12",
+			"k8s.deployment.name": "auth",
+		},
+	}, {
+		name:  "unknown replicaset",
+		owner: meta_v1.OwnerReference{Kind: "ReplicaSet", Name: "auth-service-xyz34"},
+		attributes: map[string]string{
+			"k8s.replicaset.name": "auth-service-xyz34",
+		},
+	}, {
+		name:  "cronjob",
+		owner: meta_v1.OwnerReference{Kind: "Job", Name: "backup-1604000000"},
+		attributes: map[string]string{
+			"k8s.job.name":     "backup-1604000000",
+			"k8s.cronjob.name": "backup",
+		},
+	}, {
+		name:  "daemonset",
+		owner: meta_v1.OwnerReference{Kind: "DaemonSet", Name: "agent"},
+		attributes: map[string]string{
+			"k8s.daemonset.name": "agent",
+		},
+	}, {
+		name:  "statefulset",
+		owner: meta_v1.OwnerReference{Kind: "StatefulSet", Name: "db"},
+		attributes: map[string]string{
+			"k8s.statefulset.name": "db",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            "auth-service-abc12-xyz3",
+					Namespace:       "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{tc.owner},
+				},
+				Status: api_v1.PodStatus{
+					PodIP: "1.1.1.1",
+				},
+			}
+			c.handlePodAdd(pod)
+			p, ok := c.GetPodByIP(pod.Status.PodIP)
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+}
+
+func TestNamespaceAndNodeExtraction(t *testing.T) {
+	rules := ExtractionRules{
+		NamespaceLabels: []FieldExtractionRule{
+			{Name: "team", Key: "team"},
+		},
+		NamespaceAnnotations: []FieldExtractionRule{
+			{Name: "k8s.namespace.annotations.owner", Key: "owner", Regex: regexp.MustCompile(`email=(?P<value>.+)`)},
+		},
+		NodeLabels: []FieldExtractionRule{
+			{Name: "cloud.zone", Key: "topology.kubernetes.io/zone"},
+			{Name: "host.type", Key: "node.kubernetes.io/instance-type"},
+		},
+	}
+	c, _ := newTestClientWithRulesAndFilters(t, rules, Filters{})
+	require.NotNil(t, c.namespaceInformer)
+	require.NotNil(t, c.nodeInformer)
+	assert.Nil(t, c.replicaSetInformer)
+	assert.Nil(t, c.jobInformer)
+
+	require.NoError(t, c.namespaceInformer.GetStore().Add(&api_v1.Namespace{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "ns1",
+			Labels:      map[string]string{"team": "auth"},
+			Annotations: map[string]string{"owner": "email=auth@example.com"},
+		},
+	}))
+	require.NoError(t, c.nodeInformer.GetStore().Add(&api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "node1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":      "us-west-2a",
+				"node.kubernetes.io/instance-type": "m5.large",
+			},
+		},
+	}))
+
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "auth-service-abc12-xyz3",
+			Namespace: "ns1",
+		},
+		Spec: api_v1.PodSpec{
+			NodeName: "node1",
+		},
+		Status: api_v1.PodStatus{
+			PodIP: "1.1.1.1",
+		},
+	}
+	c.handlePodAdd(pod)
+	p, ok := c.GetPodByIP(pod.Status.PodIP)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"team":                            "auth",
+		"k8s.namespace.annotations.owner": "auth@example.com",
+		"cloud.zone":                      "us-west-2a",
+		"host.type":                       "m5.large",
+	}, p.Attributes)
+
+	// pods in unknown namespaces or nodes only get their own attributes
+	pod = pod.DeepCopy()
+	pod.Namespace = "ns2"
+	pod.Spec.NodeName = "node2"
+	pod.Status.PodIP = "2.2.2.2"
+	c.handlePodAdd(pod)
+	p, ok = c.GetPodByIP(pod.Status.PodIP)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{}, p.Attributes)
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -91,6 +91,20 @@ func Test_informerWatchFuncWithSelectors(t *testing.T) {
 	assert.NotNil(t, obj)
 }
 
+func Test_metadataInformers(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	assert.NotNil(t, newNamespaceSharedInformer(client, ""))
+	assert.NotNil(t, newNodeSharedInformer(client, "node1"))
+	assert.NotNil(t, newReplicaSetSharedInformer(client, "testns"))
+	assert.NotNil(t, newJobSharedInformer(client, "testns"))
+}
+
+func Test_nameSelector(t *testing.T) {
+	assert.Equal(t, "", nameSelector("").String())
+	assert.Equal(t, "metadata.name=node1", nameSelector("node1").String())
+}
+
 func Test_fakeInformer(t *testing.T) {
 	// nothing real to test here. just to make coverage happy
 	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
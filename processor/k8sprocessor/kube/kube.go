@@ -47,6 +47,9 @@ var (
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
 	GetPodByIP(string) (*Pod, bool)
+	GetPodByUID(string) (*Pod, bool)
+	GetPodByName(namespace, name string) (*Pod, bool)
+	GetPodByContainerID(string) (*Pod, bool)
 	Start()
 	Stop()
 }
@@ -60,19 +63,31 @@ type APIClientsetProvider func(config k8sconfig.APIConfig) (kubernetes.Interface
 
 // Pod represents a kubernetes pod.
 type Pod struct {
-	Name       string
-	Address    string
-	Attributes map[string]string
-	StartTime  *metav1.Time
-	Ignore     bool
+	Name         string
+	Namespace    string
+	UID          string
+	Address      string
+	ContainerIDs []string
+	Attributes   map[string]string
+	StartTime    *metav1.Time
+	// Ignore is set when the pod can't be associated by IP, either because
+	// it was excluded or because it uses the host network.
+	Ignore bool
+	// Excluded is set when the pod must not be associated at all, because of
+	// its ignore annotation or its name.
+	Excluded bool
 
 	DeletedAt time.Time
 }
 
 type deleteRequest struct {
-	ip   string
-	name string
-	ts   time.Time
+	// ip is empty if the IP index doesn't hold the deleted pod.
+	ip           string
+	name         string
+	namespace    string
+	uid          string
+	containerIDs []string
+	ts           time.Time
 }
 
 // Filters is used to instruct the client on how to filter out k8s pods.
@@ -109,9 +124,17 @@ type ExtractionRules struct {
 	Node       bool
 	Cluster    bool
 	StartTime  bool
+	// Owners resolves the owner references of pods up the chain
+	// (ReplicaSet to Deployment, Job to CronJob). When set, the deployment
+	// name is taken from the owners instead of being guessed from the pod name.
+	Owners bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
+
+	NamespaceAnnotations []FieldExtractionRule
+	NamespaceLabels      []FieldExtractionRule
+	NodeLabels           []FieldExtractionRule
 }
 
 // FieldExtractionRule is used to specify which fields to extract from pod fields
@@ -125,12 +125,60 @@ func WithExtractAnnotations(annotations ...FieldExtractConfig) Option {
 	}
 }
 
+// WithExtractNamespaceLabels allows specifying options to control extraction of the labels of pod namespaces.
+func WithExtractNamespaceLabels(labels ...FieldExtractConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		labels, err := extractResourceFieldRules("namespace", "labels", labels...)
+		if err != nil {
+			return err
+		}
+		p.rules.NamespaceLabels = labels
+		return nil
+	}
+}
+
+// WithExtractNamespaceAnnotations allows specifying options to control extraction of the annotations of pod namespaces.
+func WithExtractNamespaceAnnotations(annotations ...FieldExtractConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		annotations, err := extractResourceFieldRules("namespace", "annotations", annotations...)
+		if err != nil {
+			return err
+		}
+		p.rules.NamespaceAnnotations = annotations
+		return nil
+	}
+}
+
+// WithExtractNodeLabels allows specifying options to control extraction of the labels of pod nodes.
+func WithExtractNodeLabels(labels ...FieldExtractConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		labels, err := extractResourceFieldRules("node", "labels", labels...)
+		if err != nil {
+			return err
+		}
+		p.rules.NodeLabels = labels
+		return nil
+	}
+}
+
+// WithOwnerLookup enables resolving the owners of pods, such as their Deployment or CronJob.
+func WithOwnerLookup() Option {
+	return func(p *kubernetesprocessor) error {
+		p.rules.Owners = true
+		return nil
+	}
+}
+
 func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
+	return extractResourceFieldRules("pod", fieldType, fields...)
+}
+
+func extractResourceFieldRules(resource, fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
 	rules := []kube.FieldExtractionRule{}
 	for _, a := range fields {
 		name := a.TagName
 		if name == "" {
-			name = fmt.Sprintf("k8s.pod.%s.%s", fieldType, a.Key)
+			name = fmt.Sprintf("k8s.%s.%s.%s", resource, fieldType, a.Key)
 		}
 
 		var r *regexp.Regexp
@@ -234,3 +282,25 @@ func WithFilterFields(filters ...FieldFilterConfig) Option {
 		return nil
 	}
 }
+
+// WithPodAssociations allows specifying rules to associate data with pods, in priority order.
+func WithPodAssociations(associations ...PodAssociationConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		rules := []podAssociation{}
+		for _, a := range associations {
+			switch a.From {
+			case associationFromConnection:
+				rules = append(rules, podAssociation{key: associationByIP})
+			case associationFromResourceAttribute:
+				if a.Name == "" {
+					return fmt.Errorf("pod association from %s requires a name", a.From)
+				}
+				rules = append(rules, podAssociation{key: associationKeyForAttribute(a.Name), attribute: a.Name})
+			default:
+				return fmt.Errorf("'%s' is not a valid pod association source", a.From)
+			}
+		}
+		p.podAssociations = rules
+		return nil
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -69,6 +70,64 @@ func TestWithPassthrough(t *testing.T) {
 	assert.True(t, p.passthroughMode)
 }
 
+func TestWithPodAssociations(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []PodAssociationConfig
+		want      []podAssociation
+		wantError string
+	}{
+		{
+			"empty",
+			[]PodAssociationConfig{},
+			[]podAssociation{},
+			"",
+		},
+		{
+			"all sources",
+			[]PodAssociationConfig{
+				{From: "resource_attribute", Name: "k8s.pod.uid"},
+				{From: "resource_attribute", Name: "k8s.pod.name"},
+				{From: "resource_attribute", Name: "container.id"},
+				{From: "resource_attribute", Name: "host.hostname"},
+				{From: "connection"},
+			},
+			[]podAssociation{
+				{key: associationByUID, attribute: "k8s.pod.uid"},
+				{key: associationByName, attribute: "k8s.pod.name"},
+				{key: associationByContainerID, attribute: "container.id"},
+				{key: associationByIP, attribute: "host.hostname"},
+				{key: associationByIP},
+			},
+			"",
+		},
+		{
+			"missing-name",
+			[]PodAssociationConfig{{From: "resource_attribute"}},
+			nil,
+			"pod association from resource_attribute requires a name",
+		},
+		{
+			"bad-source",
+			[]PodAssociationConfig{{From: "label", Name: "k8s.pod.uid"}},
+			nil,
+			"'label' is not a valid pod association source",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &kubernetesprocessor{}
+			err := WithPodAssociations(tt.args...)(p)
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.podAssociations)
+		})
+	}
+}
+
 func TestWithExtractAnnotations(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -221,6 +280,34 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.Node)
 }
 
+func TestWithExtractNamespaceAndNodeFields(t *testing.T) {
+	p := &kubernetesprocessor{}
+	fields := []FieldExtractConfig{{Key: "key1"}, {TagName: "tag2", Key: "key2"}}
+	require.NoError(t, WithExtractNamespaceLabels(fields...)(p))
+	require.NoError(t, WithExtractNamespaceAnnotations(fields...)(p))
+	require.NoError(t, WithExtractNodeLabels(fields...)(p))
+	assert.Equal(t, []kube.FieldExtractionRule{
+		{Name: "k8s.namespace.labels.key1", Key: "key1"},
+		{Name: "tag2", Key: "key2"},
+	}, p.rules.NamespaceLabels)
+	assert.Equal(t, []kube.FieldExtractionRule{
+		{Name: "k8s.namespace.annotations.key1", Key: "key1"},
+		{Name: "tag2", Key: "key2"},
+	}, p.rules.NamespaceAnnotations)
+	assert.Equal(t, []kube.FieldExtractionRule{
+		{Name: "k8s.node.labels.key1", Key: "key1"},
+		{Name: "tag2", Key: "key2"},
+	}, p.rules.NodeLabels)
+
+	assert.Error(t, WithExtractNodeLabels(FieldExtractConfig{Key: "key1", Regex: "["})(p))
+}
+
+func TestWithOwnerLookup(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, WithOwnerLookup()(p))
+	assert.True(t, p.rules.Owners)
+}
+
 func TestWithFilterLabels(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,126 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprocessor
+
+import (
+	"context"
+	"net"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
+)
+
+const (
+	associationFromResourceAttribute = "resource_attribute"
+	associationFromConnection        = "connection"
+)
+
+// associationKey is the pod index a pod association rule looks up.
+type associationKey int
+
+const (
+	associationByIP associationKey = iota
+	associationByUID
+	associationByName
+	associationByContainerID
+)
+
+// podAssociation is a validated pod_association rule.
+type podAssociation struct {
+	key associationKey
+	// attribute is the resource attribute holding the value to look up.
+	// It is empty when the connection IP is used.
+	attribute string
+}
+
+func associationKeyForAttribute(name string) associationKey {
+	switch name {
+	case conventions.AttributeK8sPodUID:
+		return associationByUID
+	case conventions.AttributeK8sPod:
+		return associationByName
+	case conventions.AttributeContainerID:
+		return associationByContainerID
+	default:
+		return associationByIP
+	}
+}
+
+// value returns the value the rule looks pods up with, or an empty string
+// if the data doesn't hold it.
+func (a podAssociation) value(ctx context.Context, resource pdata.Resource) string {
+	if a.attribute == "" {
+		if c, ok := client.FromContext(ctx); ok {
+			return c.IP
+		}
+		return ""
+	}
+	if resource.IsNil() {
+		return ""
+	}
+	return stringAttributeFromMap(resource.Attributes(), a.attribute)
+}
+
+// associatePod evaluates the pod association rules in order and returns the
+// first pod found, along with the first pod IP found in the data. In passthrough
+// mode, no pod is looked up and only the IP based rules are evaluated.
+func (kp *kubernetesprocessor) associatePod(ctx context.Context, resource pdata.Resource) (*kube.Pod, string) {
+	var podIP string
+	for _, a := range kp.podAssociations {
+		value := a.value(ctx, resource)
+		if value == "" {
+			continue
+		}
+
+		if a.key == associationByIP {
+			if net.ParseIP(value) == nil {
+				continue
+			}
+			if kp.passthroughMode {
+				return nil, value
+			}
+			if pod, ok := kp.kc.GetPodByIP(value); ok {
+				return pod, value
+			}
+			if podIP == "" {
+				podIP = value
+			}
+			continue
+		}
+
+		if kp.passthroughMode {
+			continue
+		}
+
+		var pod *kube.Pod
+		var ok bool
+		switch a.key {
+		case associationByUID:
+			pod, ok = kp.kc.GetPodByUID(value)
+		case associationByName:
+			namespace := stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8sNamespace)
+			pod, ok = kp.kc.GetPodByName(namespace, value)
+		case associationByContainerID:
+			pod, ok = kp.kc.GetPodByContainerID(value)
+		}
+		if ok {
+			return pod, podIP
+		}
+	}
+	return nil, podIP
+}
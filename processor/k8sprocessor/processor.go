@@ -38,6 +38,7 @@ type kubernetesprocessor struct {
 	passthroughMode bool
 	rules           kube.ExtractionRules
 	filters         kube.Filters
+	podAssociations []podAssociation
 }
 
 func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kube.ClientProvider) error {
@@ -114,6 +115,26 @@ func (kp *kubernetesprocessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (
 }
 
 func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pdata.Resource, attributeExtractors ...ipExtractor) {
+	if len(kp.podAssociations) > 0 {
+		pod, podIP := kp.associatePod(ctx, resource)
+		if pod == nil && podIP == "" {
+			return
+		}
+		if resource.IsNil() {
+			resource.InitEmpty()
+		}
+		attrs := resource.Attributes()
+		if podIP != "" {
+			attrs.InsertString(k8sIPLabelName, podIP)
+		}
+		if pod != nil {
+			for k, v := range pod.Attributes {
+				attrs.InsertString(k, v)
+			}
+		}
+		return
+	}
+
 	var podIP string
 
 	if !resource.IsNil() {
@@ -539,6 +539,153 @@ func TestProcessorAddLabels(t *testing.T) {
 	}
 }
 
+func TestPodAssociation(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+		WithPodAssociations(
+			PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.uid"},
+			PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.name"},
+			PodAssociationConfig{From: "resource_attribute", Name: "container.id"},
+			PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.ip"},
+			PodAssociationConfig{From: "connection"},
+		),
+	)
+
+	podByUID := &kube.Pod{Attributes: map[string]string{"pod": "by-uid"}}
+	podByName := &kube.Pod{Attributes: map[string]string{"pod": "by-name"}}
+	podByContainerID := &kube.Pod{Attributes: map[string]string{"pod": "by-container-id"}}
+	podByIP := &kube.Pod{Attributes: map[string]string{"pod": "by-ip"}}
+	podByConnection := &kube.Pod{Attributes: map[string]string{"pod": "by-connection"}}
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		fc := kp.kc.(*fakeClient)
+		fc.PodsByUID["uid-1"] = podByUID
+		fc.PodsByName["ns1/pod-1"] = podByName
+		fc.PodsByContainerID["container-1"] = podByContainerID
+		fc.Pods["1.1.1.1"] = podByIP
+		fc.Pods["3.3.3.3"] = podByConnection
+	})
+
+	testCases := []struct {
+		name      string
+		attrs     map[string]string
+		contextIP string
+		outPod    string
+		outIP     string
+	}{
+		{
+			name: "uid first",
+			attrs: map[string]string{
+				"k8s.pod.uid":        "uid-1",
+				"k8s.pod.name":       "pod-1",
+				"k8s.namespace.name": "ns1",
+				"k8s.pod.ip":         "1.1.1.1",
+			},
+			outPod: "by-uid",
+		},
+		{
+			name: "name with namespace",
+			attrs: map[string]string{
+				"k8s.pod.uid":        "unknown",
+				"k8s.pod.name":       "pod-1",
+				"k8s.namespace.name": "ns1",
+			},
+			outPod: "by-name",
+		},
+		{
+			name: "name in another namespace",
+			attrs: map[string]string{
+				"k8s.pod.name":       "pod-1",
+				"k8s.namespace.name": "ns2",
+				"container.id":       "container-1",
+			},
+			outPod: "by-container-id",
+		},
+		{
+			name: "resource IP",
+			attrs: map[string]string{
+				"k8s.pod.ip": "1.1.1.1",
+			},
+			contextIP: "3.3.3.3",
+			outPod:    "by-ip",
+			outIP:     "1.1.1.1",
+		},
+		{
+			name: "unknown resource IP",
+			attrs: map[string]string{
+				"k8s.pod.ip": "2.2.2.2",
+			},
+			contextIP: "3.3.3.3",
+			outPod:    "by-connection",
+			outIP:     "3.3.3.3",
+		},
+		{
+			name: "no pod",
+			attrs: map[string]string{
+				"k8s.pod.ip": "2.2.2.2",
+			},
+			outIP: "2.2.2.2",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.contextIP != "" {
+				ctx = client.NewContext(ctx, &client.Client{IP: tc.contextIP})
+			}
+			withAttrs := func(res pdata.Resource) {
+				for k, v := range tc.attrs {
+					res.Attributes().InsertString(k, v)
+				}
+			}
+
+			m.testConsume(ctx, generateTraces(withAttrs), generateMetrics(withAttrs), generateLogs(withAttrs), nil)
+			m.assertBatchesLen(i + 1)
+			m.assertResource(i, 0, func(res pdata.Resource) {
+				require.False(t, res.IsNil())
+				if tc.outPod != "" {
+					assertResourceHasStringAttribute(t, res, "pod", tc.outPod)
+				} else {
+					_, ok := res.Attributes().Get("pod")
+					assert.False(t, ok)
+				}
+				if tc.outIP != "" {
+					assertResourceHasStringAttribute(t, res, "k8s.pod.ip", tc.outIP)
+				}
+			})
+		})
+	}
+}
+
+func TestPodAssociationPassthrough(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+		WithPassthrough(),
+		WithPodAssociations(
+			PodAssociationConfig{From: "resource_attribute", Name: "k8s.pod.uid"},
+			PodAssociationConfig{From: "resource_attribute", Name: "host.hostname"},
+			PodAssociationConfig{From: "connection"},
+		),
+	)
+
+	withAttrs := func(res pdata.Resource) {
+		res.Attributes().InsertString("k8s.pod.uid", "uid-1")
+		res.Attributes().InsertString("host.hostname", "not-an-ip")
+	}
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "3.3.3.3"})
+	m.testConsume(ctx, generateTraces(withAttrs), generateMetrics(withAttrs), generateLogs(withAttrs), nil)
+
+	m.assertBatchesLen(1)
+	m.assertResourceAttributesLen(0, 0, 3)
+	m.assertResource(0, 0, func(res pdata.Resource) {
+		assertResourceHasStringAttribute(t, res, "k8s.pod.ip", "3.3.3.3")
+	})
+}
+
 func TestProcessorPicksUpPassthoughPodIp(t *testing.T) {
 	m := newMultiTest(
 		t,
@@ -20,6 +20,9 @@ const (
 	// MetricNameFieldName is the mapstructure field name for MetricName field
 	MetricNameFieldName = "metric_name"
 
+	// MatchTypeFieldName is the mapstructure field name for MatchType field
+	MatchTypeFieldName = "match_type"
+
 	// ActionFieldName is the mapstructure field name for Action field
 	ActionFieldName = "action"
 
@@ -50,12 +53,17 @@ type Transform struct {
 	// REQUIRED
 	MetricName string `mapstructure:"metric_name"`
 
+	// MatchType determines how MetricName is matched against the metric names.
+	// Defaults to strict. When set to regexp, MetricName is a regular expression
+	// and NewName may reference its capture groups (e.g. $1 or ${1}).
+	MatchType MatchType `mapstructure:"match_type"`
+
 	// Action specifies the action performed on the matched metric.
 	// REQUIRED
 	Action ConfigAction `mapstructure:"action"`
 
-	// NewName specifies the name of the new metric when inserting or updating.
-	// REQUIRED only if Action is INSERT.
+	// NewName specifies the name of the new metric when inserting, updating or combining.
+	// REQUIRED only if Action is INSERT or COMBINE.
 	NewName string `mapstructure:"new_name"`
 
 	// Operations contains a list of operations that will be performed on the selected metric.
@@ -102,7 +110,10 @@ type ValueAction struct {
 	NewValue string `mapstructure:"new_value"`
 }
 
-// ConfigAction is the enum to capture the two types of actions to perform on a metric.
+// MatchType is the enum to capture the two ways of matching metric names.
+type MatchType string
+
+// ConfigAction is the enum to capture the three types of actions to perform on a metric.
 type ConfigAction string
 
 // OperationAction is the enum to capture the thress types of actions to perform for an operation.
@@ -112,12 +123,22 @@ type OperationAction string
 type AggregationType string
 
 const (
+	// StrictMatchType matches the metric name exactly.
+	StrictMatchType MatchType = "strict"
+
+	// RegexpMatchType matches the metric name against a regular expression.
+	RegexpMatchType MatchType = "regexp"
+
 	// Insert adds a new metric to the batch with a new name.
 	Insert ConfigAction = "insert"
 
 	// Update updates an existing metric.
 	Update ConfigAction = "update"
 
+	// Combine combines all metrics matched by the regular expression into a single metric,
+	// adding a label for each named capture group of the expression.
+	Combine ConfigAction = "combine"
+
 	// ToggleScalarDataType changes the data type from int64 to double, or vice-versa
 	ToggleScalarDataType OperationAction = "toggle_scalar_data_type"
 
@@ -101,6 +101,29 @@ var (
 				},
 			},
 		},
+		{
+			filterName: "metricstransform/regexp",
+			expCfg: &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					NameVal: "metricstransform/regexp",
+					TypeVal: typeStr,
+				},
+				Transforms: []Transform{
+					{
+						MetricName: `^system\.cpu\.`,
+						MatchType:  RegexpMatchType,
+						Action:     Update,
+						Operations: []Operation{
+							{
+								Action:   AddLabel,
+								NewLabel: "source",
+								NewValue: "host",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 )
 
@@ -17,6 +17,7 @@ package metricstransformprocessor
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
@@ -77,12 +78,33 @@ func validateConfiguration(config *Config) error {
 			return fmt.Errorf("missing required field %q", MetricNameFieldName)
 		}
 
-		if transform.Action != Update && transform.Action != Insert {
-			return fmt.Errorf("unsupported %q: %v, the supported actions are %q and %q", ActionFieldName, transform.Action, Insert, Update)
+		var pattern *regexp.Regexp
+		switch transform.MatchType {
+		case "", StrictMatchType:
+		case RegexpMatchType:
+			var err error
+			if pattern, err = regexp.Compile(transform.MetricName); err != nil {
+				return fmt.Errorf("%q, %v, is not a valid regular expression: %v", MetricNameFieldName, transform.MetricName, err)
+			}
+		default:
+			return fmt.Errorf("unsupported %q: %v, the supported match types are %q and %q", MatchTypeFieldName, transform.MatchType, StrictMatchType, RegexpMatchType)
+		}
+
+		if transform.Action != Update && transform.Action != Insert && transform.Action != Combine {
+			return fmt.Errorf("unsupported %q: %v, the supported actions are %q, %q and %q", ActionFieldName, transform.Action, Insert, Update, Combine)
+		}
+
+		if (transform.Action == Insert || transform.Action == Combine) && transform.NewName == "" {
+			return fmt.Errorf("missing required field %q while %q is %v", NewNameFieldName, ActionFieldName, transform.Action)
 		}
 
-		if transform.Action == Insert && transform.NewName == "" {
-			return fmt.Errorf("missing required field %q while %q is %v", NewNameFieldName, ActionFieldName, Insert)
+		if transform.Action == Combine {
+			if pattern == nil {
+				return fmt.Errorf("%q must be %q while %q is %v", MatchTypeFieldName, RegexpMatchType, ActionFieldName, Combine)
+			}
+			if len(submatchNames(pattern)) == 0 {
+				return fmt.Errorf("%q, %v, must contain at least one named capture group while %q is %v", MetricNameFieldName, transform.MetricName, ActionFieldName, Combine)
+			}
 		}
 
 		for i, op := range transform.Operations {
@@ -110,6 +132,9 @@ func buildHelperConfig(config *Config, version string) []internalTransform {
 			NewName:    t.NewName,
 			Operations: make([]internalOperation, len(t.Operations)),
 		}
+		if t.MatchType == RegexpMatchType {
+			helperT.MetricNamePattern = regexp.MustCompile(t.MetricName)
+		}
 		for j, op := range t.Operations {
 			op.NewValue = strings.ReplaceAll(op.NewValue, "{{version}}", version)
 
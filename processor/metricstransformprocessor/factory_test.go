@@ -65,7 +65,7 @@ func TestCreateProcessors(t *testing.T) {
 		}, {
 			configName:   "config_invalid_action.yaml",
 			succeed:      false,
-			errorMessage: fmt.Sprintf("unsupported %q: %v, the supported actions are %q and %q", ActionFieldName, "invalid", Insert, Update),
+			errorMessage: fmt.Sprintf("unsupported %q: %v, the supported actions are %q, %q and %q", ActionFieldName, "invalid", Insert, Update, Combine),
 		}, {
 			configName:   "config_invalid_metricname.yaml",
 			succeed:      false,
@@ -74,6 +74,14 @@ func TestCreateProcessors(t *testing.T) {
 			configName:   "config_invalid_label.yaml",
 			succeed:      false,
 			errorMessage: fmt.Sprintf("missing required field %q while %q is %v in the %vth operation", LabelFieldName, ActionFieldName, UpdateLabel, 0),
+		}, {
+			configName:   "config_invalid_matchtype.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("unsupported %q: %v, the supported match types are %q and %q", MatchTypeFieldName, "invalid", StrictMatchType, RegexpMatchType),
+		}, {
+			configName:   "config_invalid_regexp.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("%q, %v, is not a valid regular expression: %v", MetricNameFieldName, "(old_name", "error parsing regexp: missing closing ): `(old_name`"),
 		},
 	}
 
@@ -148,6 +156,49 @@ func TestFactory_validateConfiguration(t *testing.T) {
 
 	err = validateConfiguration(&v2)
 	assert.Equal(t, "missing required field \"new_value\" while \"action\" is add_label in the 0th operation", err.Error())
+
+	v3 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: `^system\.cpu\.(?P<state>.*)\.time$`,
+				Action:     Combine,
+				NewName:    "system.cpu.time",
+			},
+		},
+	}
+
+	err = validateConfiguration(&v3)
+	assert.Equal(t, "\"match_type\" must be \"regexp\" while \"action\" is combine", err.Error())
+
+	v4 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: `^system\.cpu\.(.*)\.time$`,
+				MatchType:  RegexpMatchType,
+				Action:     Combine,
+				NewName:    "system.cpu.time",
+			},
+		},
+	}
+
+	err = validateConfiguration(&v4)
+	assert.Equal(t, "\"metric_name\", ^system\\.cpu\\.(.*)\\.time$, must contain at least one named capture group while \"action\" is combine", err.Error())
+
+	v5 := Config{
+		Transforms: []Transform{
+			{
+				MetricName: `^system\.cpu\.(?P<state>.*)\.time$`,
+				MatchType:  RegexpMatchType,
+				Action:     Combine,
+			},
+		},
+	}
+
+	err = validateConfiguration(&v5)
+	assert.Equal(t, "missing required field \"new_name\" while \"action\" is combine", err.Error())
+
+	v5.Transforms[0].NewName = "system.cpu.time"
+	assert.NoError(t, validateConfiguration(&v5))
 }
 
 func TestCreateProcessorsFilledData(t *testing.T) {
@@ -252,6 +303,7 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 
 	for i, expTr := range expData {
 		mtpT := internalTransforms[i]
+		assert.Nil(t, mtpT.MetricNamePattern)
 		assert.Equal(t, expTr.NewName, mtpT.NewName)
 		assert.Equal(t, expTr.Action, mtpT.Action)
 		assert.Equal(t, expTr.MetricName, mtpT.MetricName)
@@ -264,3 +316,23 @@ func TestCreateProcessorsFilledData(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildHelperConfigRegexp(t *testing.T) {
+	oCfg := &Config{
+		Transforms: []Transform{
+			{
+				MetricName: `^system\.(.*)$`,
+				MatchType:  RegexpMatchType,
+				Action:     Insert,
+				NewName:    "host.$1",
+			},
+		},
+	}
+	assert.NoError(t, validateConfiguration(oCfg))
+
+	internalTransforms := buildHelperConfig(oCfg, "v0.0.1")
+	assert.Len(t, internalTransforms, 1)
+	assert.NotNil(t, internalTransforms[0].MetricNamePattern)
+	assert.True(t, internalTransforms[0].MetricNamePattern.MatchString("system.cpu.time"))
+	assert.False(t, internalTransforms[0].MetricNamePattern.MatchString("process.cpu.time"))
+}
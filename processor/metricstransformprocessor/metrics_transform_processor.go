@@ -16,6 +16,8 @@ package metricstransformprocessor
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -28,9 +30,11 @@ import (
 
 type internalTransform struct {
 	MetricName string
-	Action     ConfigAction
-	NewName    string
-	Operations []internalOperation
+	// MetricNamePattern is set when the transform matches metric names by regexp.
+	MetricNamePattern *regexp.Regexp
+	Action            ConfigAction
+	NewName           string
+	Operations        []internalOperation
 }
 
 type internalOperation struct {
@@ -66,23 +70,45 @@ func (mtp *metricsTransformProcessor) ProcessMetrics(_ context.Context, md pdata
 		}
 
 		for _, transform := range mtp.transforms {
-			metric, ok := nameToMetricMapping[transform.MetricName]
-			if !ok {
+			matchedMetrics := mtp.matchMetrics(transform, data.Metrics, nameToMetricMapping)
+
+			if transform.Action == Combine {
+				if len(matchedMetrics) == 0 {
+					continue
+				}
+				if err := mtp.canBeCombined(matchedMetrics, transform); err != nil {
+					mtp.logger.Warn("Failed to combine metrics", zap.String("new_name", transform.NewName), zap.Error(err))
+					continue
+				}
+
+				combined := mtp.combine(matchedMetrics, transform)
+				data.Metrics = removeMetrics(data.Metrics, matchedMetrics)
+				for _, metric := range matchedMetrics {
+					delete(nameToMetricMapping, metric.MetricDescriptor.Name)
+				}
+				data.Metrics = append(data.Metrics, combined)
+				nameToMetricMapping[combined.MetricDescriptor.Name] = combined
+
+				mtp.update(combined, transform)
 				continue
 			}
 
-			if transform.Action == Insert {
-				metric = proto.Clone(metric).(*metricspb.Metric)
-				data.Metrics = append(data.Metrics, metric)
-			}
+			for _, metric := range matchedMetrics {
+				metricName := metric.MetricDescriptor.Name
+
+				if transform.Action == Insert {
+					metric = proto.Clone(metric).(*metricspb.Metric)
+					data.Metrics = append(data.Metrics, metric)
+				}
 
-			mtp.update(metric, transform)
+				mtp.update(metric, transform)
 
-			if transform.NewName != "" {
-				if transform.Action == Update {
-					delete(nameToMetricMapping, transform.MetricName)
+				if transform.NewName != "" {
+					if transform.Action == Update {
+						delete(nameToMetricMapping, metricName)
+					}
+					nameToMetricMapping[metric.MetricDescriptor.Name] = metric
 				}
-				nameToMetricMapping[transform.NewName] = metric
 			}
 		}
 	}
@@ -90,10 +116,29 @@ func (mtp *metricsTransformProcessor) ProcessMetrics(_ context.Context, md pdata
 	return internaldata.OCSliceToMetrics(mds), nil
 }
 
+// matchMetrics returns the metrics selected by the transform, either by exact name or
+// by matching the name against the transform's regular expression.
+func (mtp *metricsTransformProcessor) matchMetrics(transform internalTransform, metrics []*metricspb.Metric, nameToMetricMapping map[string]*metricspb.Metric) []*metricspb.Metric {
+	if transform.MetricNamePattern == nil {
+		if metric, ok := nameToMetricMapping[transform.MetricName]; ok {
+			return []*metricspb.Metric{metric}
+		}
+		return nil
+	}
+
+	var matches []*metricspb.Metric
+	for _, metric := range metrics {
+		if transform.MetricNamePattern.MatchString(metric.MetricDescriptor.Name) {
+			matches = append(matches, metric)
+		}
+	}
+	return matches
+}
+
 // update updates the metric content based on operations indicated in transform.
 func (mtp *metricsTransformProcessor) update(metric *metricspb.Metric, transform internalTransform) {
-	if transform.NewName != "" {
-		metric.MetricDescriptor.Name = transform.NewName
+	if transform.NewName != "" && transform.Action != Combine {
+		metric.MetricDescriptor.Name = mtp.newName(metric.MetricDescriptor.Name, transform)
 	}
 
 	for _, op := range transform.Operations {
@@ -114,6 +159,144 @@ func (mtp *metricsTransformProcessor) update(metric *metricspb.Metric, transform
 	}
 }
 
+// newName returns the new name of the metric, expanding any capture groups of the
+// transform's regular expression referenced in NewName.
+func (mtp *metricsTransformProcessor) newName(name string, transform internalTransform) string {
+	if transform.MetricNamePattern == nil {
+		return transform.NewName
+	}
+	match := transform.MetricNamePattern.FindStringSubmatchIndex(name)
+	return string(transform.MetricNamePattern.ExpandString(nil, transform.NewName, name, match))
+}
+
+// canBeCombined returns an error if the matched metrics don't share the same type, unit and
+// label keys, or if their label keys collide with the labels added by the combine action.
+func (mtp *metricsTransformProcessor) canBeCombined(metrics []*metricspb.Metric, transform internalTransform) error {
+	first := metrics[0].MetricDescriptor
+	firstLabelKeys := make(map[string]bool, len(first.LabelKeys))
+	for _, label := range first.LabelKeys {
+		firstLabelKeys[label.Key] = true
+	}
+
+	for _, name := range submatchNames(transform.MetricNamePattern) {
+		if firstLabelKeys[name] {
+			return fmt.Errorf("metric %v already has a label named %v", first.Name, name)
+		}
+	}
+
+	for _, metric := range metrics[1:] {
+		descriptor := metric.MetricDescriptor
+		if descriptor.Type != first.Type {
+			return fmt.Errorf("metrics %v (%v) and %v (%v) have different types", first.Name, first.Type, descriptor.Name, descriptor.Type)
+		}
+		if descriptor.Unit != first.Unit {
+			return fmt.Errorf("metrics %v (%q) and %v (%q) have different units", first.Name, first.Unit, descriptor.Name, descriptor.Unit)
+		}
+		if len(descriptor.LabelKeys) != len(first.LabelKeys) {
+			return fmt.Errorf("metrics %v and %v have different labels", first.Name, descriptor.Name)
+		}
+		for _, label := range descriptor.LabelKeys {
+			if !firstLabelKeys[label.Key] {
+				return fmt.Errorf("metrics %v and %v have different labels", first.Name, descriptor.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// combine merges the timeseries of the matched metrics into a single metric named after the
+// transform's NewName, adding a label for each named capture group of the regular expression.
+// The label values of each timeseries are reordered to follow the label keys of the first metric.
+func (mtp *metricsTransformProcessor) combine(metrics []*metricspb.Metric, transform internalTransform) *metricspb.Metric {
+	first := metrics[0].MetricDescriptor
+	labelKeys := make([]*metricspb.LabelKey, 0, len(first.LabelKeys))
+	labelKeys = append(labelKeys, first.LabelKeys...)
+
+	subexpNames := transform.MetricNamePattern.SubexpNames()
+	for _, name := range subexpNames {
+		if name != "" {
+			labelKeys = append(labelKeys, &metricspb.LabelKey{Key: name})
+		}
+	}
+
+	combined := &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        transform.NewName,
+			Description: first.Description,
+			Unit:        first.Unit,
+			Type:        first.Type,
+			LabelKeys:   labelKeys,
+		},
+		Resource: metrics[0].Resource,
+	}
+
+	for _, metric := range metrics {
+		name := metric.MetricDescriptor.Name
+		match := transform.MetricNamePattern.FindStringSubmatchIndex(name)
+
+		labelIdxs := make([]int, len(first.LabelKeys))
+		for i, firstLabel := range first.LabelKeys {
+			for j, label := range metric.MetricDescriptor.LabelKeys {
+				if label.Key == firstLabel.Key {
+					labelIdxs[i] = j
+					break
+				}
+			}
+		}
+
+		for _, timeseries := range metric.Timeseries {
+			labelValues := make([]*metricspb.LabelValue, 0, len(labelKeys))
+			for _, idx := range labelIdxs {
+				labelValues = append(labelValues, timeseries.LabelValues[idx])
+			}
+			for i, subexpName := range subexpNames {
+				if subexpName == "" {
+					continue
+				}
+				if match[2*i] < 0 {
+					labelValues = append(labelValues, &metricspb.LabelValue{})
+					continue
+				}
+				labelValues = append(labelValues, &metricspb.LabelValue{
+					Value:    name[match[2*i]:match[2*i+1]],
+					HasValue: true,
+				})
+			}
+			timeseries.LabelValues = labelValues
+			combined.Timeseries = append(combined.Timeseries, timeseries)
+		}
+	}
+
+	return combined
+}
+
+// removeMetrics returns the metrics without the ones in toRemove, preserving their order.
+func removeMetrics(metrics []*metricspb.Metric, toRemove []*metricspb.Metric) []*metricspb.Metric {
+	removeSet := make(map[*metricspb.Metric]bool, len(toRemove))
+	for _, metric := range toRemove {
+		removeSet[metric] = true
+	}
+
+	kept := make([]*metricspb.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if !removeSet[metric] {
+			kept = append(kept, metric)
+		}
+	}
+	return kept
+}
+
+// submatchNames returns the names of the named capture groups of the pattern.
+func submatchNames(pattern *regexp.Regexp) []string {
+	names := make([]string, 0)
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // getLabelIdxs gets the indices of the labelSet labels' indices in the metric's descriptor's labels field
 // Returns the indices slice and a slice of the actual labels selected by this slice of indices
 func (mtp *metricsTransformProcessor) getLabelIdxs(metric *metricspb.Metric, labelSet map[string]bool) ([]int, []*metricspb.LabelKey) {
@@ -15,6 +15,8 @@
 package metricstransformprocessor
 
 import (
+	"regexp"
+
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 )
 
@@ -570,6 +572,91 @@ var (
 					build(),
 			},
 		},
+		{
+			name: "metric_name_update_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.(.*)$",
+					MetricNamePattern: regexp.MustCompile("^system\\.(.*)$"),
+					Action:            Update,
+					NewName:           "host.$1",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("process.cpu.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("system.memory.usage").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("host.cpu.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("process.cpu.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("host.memory.usage").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+		},
+		{
+			name: "metric_name_update_regexp_named_group",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^(?P<namespace>[^.]+)\\.cpu$",
+					MetricNamePattern: regexp.MustCompile("^(?P<namespace>[^.]+)\\.cpu$"),
+					Action:            Update,
+					NewName:           "cpu/${namespace}",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("container.cpu").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("container.cpu.limit").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("cpu/container").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+				metricBuilder().setName("container.cpu.limit").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+			},
+		},
+		{
+			name: "metric_label_update_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^metric[0-9]$",
+					MetricNamePattern: regexp.MustCompile("^metric[0-9]$"),
+					Action:            Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:   UpdateLabel,
+								Label:    "label1",
+								NewLabel: "new/label1",
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("metric2").setLabels([]string{"label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("metric10").setLabels([]string{"label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"new/label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("metric2").setLabels([]string{"new/label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("metric10").setLabels([]string{"label1"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+		},
 		// INSERT
 		{
 			name: "metric_name_insert",
@@ -616,6 +703,29 @@ var (
 				metricBuilder().setName("new/metric2").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
 			},
 		},
+		{
+			name: "metric_name_insert_regexp",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.(.*)$",
+					MetricNamePattern: regexp.MustCompile("^system\\.(.*)$"),
+					Action:            Insert,
+					NewName:           "host.$1",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.time").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("process.cpu.time").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.memory.usage").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.time").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("process.cpu.time").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.memory.usage").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("host.cpu.time").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("host.memory.usage").setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+		},
 		{
 			name: "metric_label_update_with_metric_insert",
 			transforms: []internalTransform{
@@ -818,6 +928,147 @@ var (
 					build(),
 			},
 		},
+		// COMBINE
+		{
+			name: "metric_combine",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.cpu\\.(?P<state>.*)\\.time$",
+					MetricNamePattern: regexp.MustCompile("^system\\.cpu\\.(?P<state>.*)\\.time$"),
+					Action:            Combine,
+					NewName:           "system.cpu.time",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").setLabels([]string{"cpu", "mode"}).
+					setDataType(metricspb.MetricDescriptor_CUMULATIVE_INT64).
+					addTimeseries(1, []string{"cpu0", "normal"}).
+					addInt64Point(0, 3, 2).
+					build(),
+				metricBuilder().setName("process.cpu.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_CUMULATIVE_INT64).
+					addTimeseries(1, []string{"cpu0"}).
+					addInt64Point(0, 1, 2).
+					build(),
+				metricBuilder().setName("system.cpu.idle.time").setLabels([]string{"mode", "cpu"}).
+					setDataType(metricspb.MetricDescriptor_CUMULATIVE_INT64).
+					addTimeseries(1, []string{"normal", "cpu0"}).
+					addInt64Point(0, 5, 2).
+					addTimeseries(1, []string{"normal", "cpu1"}).
+					addInt64Point(1, 6, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("process.cpu.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_CUMULATIVE_INT64).
+					addTimeseries(1, []string{"cpu0"}).
+					addInt64Point(0, 1, 2).
+					build(),
+				metricBuilder().setName("system.cpu.time").setLabels([]string{"cpu", "mode", "state"}).
+					setDataType(metricspb.MetricDescriptor_CUMULATIVE_INT64).
+					addTimeseries(1, []string{"cpu0", "normal", "user"}).
+					addInt64Point(0, 3, 2).
+					addTimeseries(1, []string{"cpu0", "normal", "idle"}).
+					addInt64Point(1, 5, 2).
+					addTimeseries(1, []string{"cpu1", "normal", "idle"}).
+					addInt64Point(2, 6, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_combine_with_operations",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.cpu\\.(?P<state>.*)\\.time$",
+					MetricNamePattern: regexp.MustCompile("^system\\.cpu\\.(?P<state>.*)\\.time$"),
+					Action:            Combine,
+					NewName:           "system.cpu.time",
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: AggregateLabels,
+								LabelSet: []string{
+									"state",
+								},
+								AggregationType: Sum,
+							},
+							labelSetMap: map[string]bool{
+								"state": true,
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"cpu0"}).
+					addInt64Point(0, 3, 2).
+					addTimeseries(1, []string{"cpu1"}).
+					addInt64Point(1, 4, 2).
+					build(),
+				metricBuilder().setName("system.cpu.idle.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(2, []string{"cpu0"}).
+					addInt64Point(0, 5, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.time").setLabels([]string{"state"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"user"}).
+					addInt64Point(0, 7, 2).
+					addTimeseries(2, []string{"idle"}).
+					addInt64Point(1, 5, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_combine_different_types",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.cpu\\.(?P<state>.*)\\.time$",
+					MetricNamePattern: regexp.MustCompile("^system\\.cpu\\.(?P<state>.*)\\.time$"),
+					Action:            Combine,
+					NewName:           "system.cpu.time",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.cpu.idle.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.cpu.idle.time").
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).build(),
+			},
+		},
+		{
+			name: "metric_combine_different_labels",
+			transforms: []internalTransform{
+				{
+					MetricName:        "^system\\.cpu\\.(?P<state>.*)\\.time$",
+					MetricNamePattern: regexp.MustCompile("^system\\.cpu\\.(?P<state>.*)\\.time$"),
+					Action:            Combine,
+					NewName:           "system.cpu.time",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.cpu.idle.time").setLabels([]string{"core"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("system.cpu.user.time").setLabels([]string{"cpu"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+				metricBuilder().setName("system.cpu.idle.time").setLabels([]string{"core"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).build(),
+			},
+		},
 		// Toggle Data Type
 		{
 			name: "metric_toggle_scalar_data_type_int64_to_double",
@@ -32,4 +32,24 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// Attributes is an allow-list of the attributes to keep, by detector name.
+	// Detectors without an entry keep all of their attributes.
+	Attributes map[string][]string `mapstructure:"attributes"`
+	// MergePolicies sets how detected attributes are merged with existing
+	// resource attributes of the same name. Attributes without a merge
+	// policy follow Override.
+	MergePolicies []AttributeMergePolicy `mapstructure:"merge_policies"`
+	// RefreshInterval is the interval at which the resource information is
+	// detected again. The resource is only detected on start if it is not set.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// AttributeMergePolicy sets the merge policy of one attribute.
+type AttributeMergePolicy struct {
+	// Attribute is the name of the attribute.
+	Attribute string `mapstructure:"attribute"`
+	// Policy is either "keep" to keep the existing value, "override" to
+	// override it, or "skip_if_empty" to override it unless the detected
+	// value is empty.
+	Policy string `mapstructure:"policy"`
 }
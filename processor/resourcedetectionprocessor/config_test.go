@@ -59,5 +59,24 @@ func TestLoadConfig(t *testing.T) {
 		Detectors: []string{"env", "ec2"},
 		Timeout:   2 * time.Second,
 		Override:  false,
+		Attributes: map[string][]string{
+			"ec2": {"cloud.region", "cloud.zone"},
+		},
+		MergePolicies: []AttributeMergePolicy{
+			{Attribute: "cloud.region", Policy: "override"},
+			{Attribute: "host.name", Policy: "skip_if_empty"},
+		},
+		RefreshInterval: 5 * time.Minute,
+	})
+
+	p4 := cfg.Processors["resourcedetection/system"]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/system",
+		},
+		Detectors: []string{"env", "system", "docker"},
+		Timeout:   2 * time.Second,
+		Override:  false,
 	})
 }
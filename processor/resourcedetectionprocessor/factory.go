@@ -16,6 +16,7 @@ package resourcedetectionprocessor
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -29,8 +30,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ecs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/elasticbeanstalk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/cloudrun"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gke"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 const (
@@ -52,10 +60,17 @@ type factory struct {
 // NewFactory creates a new factory for ResourceDetection processor.
 func NewFactory() component.ProcessorFactory {
 	resourceProviderFactory := internal.NewProviderFactory(map[internal.DetectorType]internal.DetectorFactory{
-		env.TypeStr: env.NewDetector,
-		gce.TypeStr: gce.NewDetector,
-		ec2.TypeStr: ec2.NewDetector,
-		ecs.TypeStr: ecs.NewDetector,
+		env.TypeStr:              env.NewDetector,
+		gce.TypeStr:              gce.NewDetector,
+		gke.TypeStr:              gke.NewDetector,
+		cloudrun.TypeStr:         cloudrun.NewDetector,
+		ec2.TypeStr:              ec2.NewDetector,
+		ecs.TypeStr:              ecs.NewDetector,
+		eks.TypeStr:              eks.NewDetector,
+		elasticbeanstalk.TypeStr: elasticbeanstalk.NewDetector,
+		azure.TypeStr:            azure.NewDetector,
+		docker.TypeStr:           docker.NewDetector,
+		system.TypeStr:           system.NewDetector,
 	})
 
 	f := &factory{
@@ -104,7 +119,8 @@ func (f *factory) createTraceProcessor(
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) createMetricsProcessor(
@@ -123,7 +139,8 @@ func (f *factory) createMetricsProcessor(
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) createLogsProcessor(
@@ -142,7 +159,8 @@ func (f *factory) createLogsProcessor(
 		nextConsumer,
 		rdp,
 		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithStart(rdp.Start))
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithShutdown(rdp.Shutdown))
 }
 
 func (f *factory) getResourceDetectionProcessor(
@@ -151,22 +169,43 @@ func (f *factory) getResourceDetectionProcessor(
 ) (*resourceDetectionProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	provider, err := f.getResourceProvider(logger, cfg.Name(), oCfg.Timeout, oCfg.Detectors)
+	policies, err := mergePolicies(oCfg.MergePolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := f.getResourceProvider(logger, cfg.Name(), oCfg)
 	if err != nil {
 		return nil, err
 	}
 
 	return &resourceDetectionProcessor{
-		provider: provider,
-		override: oCfg.Override,
+		logger:          logger,
+		provider:        provider,
+		override:        oCfg.Override,
+		policies:        policies,
+		refreshInterval: oCfg.RefreshInterval,
+		done:            make(chan struct{}),
 	}, nil
 }
 
+func mergePolicies(configured []AttributeMergePolicy) (map[string]internal.MergePolicy, error) {
+	policies := make(map[string]internal.MergePolicy, len(configured))
+	for _, p := range configured {
+		switch policy := internal.MergePolicy(p.Policy); policy {
+		case internal.MergePolicyKeep, internal.MergePolicyOverride, internal.MergePolicySkipIfEmpty:
+			policies[p.Attribute] = policy
+		default:
+			return nil, fmt.Errorf("invalid merge policy %q for attribute %q", p.Policy, p.Attribute)
+		}
+	}
+	return policies, nil
+}
+
 func (f *factory) getResourceProvider(
 	logger *zap.Logger,
 	processorName string,
-	timeout time.Duration,
-	configuredDetectors []string,
+	oCfg *Config,
 ) (*internal.ResourceProvider, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -175,12 +214,21 @@ func (f *factory) getResourceProvider(
 		return provider, nil
 	}
 
-	detectorTypes := make([]internal.DetectorType, 0, len(configuredDetectors))
-	for _, key := range configuredDetectors {
+	detectorTypes := make([]internal.DetectorType, 0, len(oCfg.Detectors))
+	for _, key := range oCfg.Detectors {
 		detectorTypes = append(detectorTypes, internal.DetectorType(strings.TrimSpace(key)))
 	}
 
-	provider, err := f.resourceProviderFactory.CreateResourceProvider(logger, timeout, detectorTypes...)
+	settings := internal.ProviderSettings{
+		Timeout:         oCfg.Timeout,
+		RefreshInterval: oCfg.RefreshInterval,
+		Attributes:      make(map[internal.DetectorType][]string, len(oCfg.Attributes)),
+	}
+	for key, attributes := range oCfg.Attributes {
+		settings.Attributes[internal.DetectorType(key)] = attributes
+	}
+
+	provider, err := f.resourceProviderFactory.CreateResourceProviderWithSettings(logger, settings, detectorTypes...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "eks"
+
+	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+	kubernetesServicePortEnvVar = "KUBERNETES_SERVICE_PORT"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an EKS detector
+type Detector struct {
+	provider eksMetadataProvider
+}
+
+// NewDetector creates a new EKS detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{provider: newEKSMetadataProvider(os.Getenv(kubernetesServiceHostEnvVar), os.Getenv(kubernetesServicePortEnvVar))}, nil
+}
+
+// Detect returns a resource describing the EKS cluster the collector is running in,
+// or an empty resource when it is not running in Kubernetes or not on EKS.
+// TODO: Replace all attribute fields and enums with values defined in "conventions" once they exist
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	// Fail fast if not running in Kubernetes
+	if os.Getenv(kubernetesServiceHostEnvVar) == "" {
+		return res, nil
+	}
+
+	isEKS, err := d.provider.isEKS(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed checking if running on EKS: %w", err)
+	}
+	if !isEKS {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attr.InsertString("cloud.infrastructure_service", "EKS")
+
+	return res, nil
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadataProvider struct {
+	eks bool
+	err error
+}
+
+var _ eksMetadataProvider = (*mockMetadataProvider)(nil)
+
+func (m *mockMetadataProvider) isEKS(context.Context) (bool, error) {
+	return m.eks, m.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectNotInKubernetes(t *testing.T) {
+	os.Unsetenv(kubernetesServiceHostEnvVar)
+	d := &Detector{provider: &mockMetadataProvider{eks: true}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetect(t *testing.T) {
+	os.Setenv(kubernetesServiceHostEnvVar, "10.100.0.1")
+	defer os.Unsetenv(kubernetesServiceHostEnvVar)
+
+	tests := []struct {
+		name     string
+		provider *mockMetadataProvider
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "on EKS",
+			provider: &mockMetadataProvider{eks: true},
+			want: map[string]interface{}{
+				"cloud.provider":               "aws",
+				"cloud.infrastructure_service": "EKS",
+			},
+		},
+		{
+			name:     "not on EKS",
+			provider: &mockMetadataProvider{},
+			want:     map[string]interface{}{},
+		},
+		{
+			name:     "error",
+			provider: &mockMetadataProvider{err: errors.New("forbidden")},
+			want:     map[string]interface{}{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{provider: tt.provider}
+			res, err := d.Detect(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// EKS clusters are the only ones holding the aws-auth config map, mapping IAM
+	// roles to Kubernetes users.
+	authConfigMapPath = "/api/v1/namespaces/kube-system/configmaps/aws-auth"
+
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+type eksMetadataProvider interface {
+	isEKS(ctx context.Context) (bool, error)
+}
+
+type eksMetadataProviderImpl struct {
+	endpoint  string
+	tokenFile string
+	client    *http.Client
+}
+
+var _ eksMetadataProvider = (*eksMetadataProviderImpl)(nil)
+
+// newEKSMetadataProvider creates a provider querying the Kubernetes API server
+// with the credentials of the service account of the pod.
+func newEKSMetadataProvider(host, port string) *eksMetadataProviderImpl {
+	tlsConfig := &tls.Config{}
+	if ca, err := ioutil.ReadFile(serviceAccountCAFile); err == nil {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(ca)
+		tlsConfig.RootCAs = pool
+	}
+
+	return &eksMetadataProviderImpl{
+		endpoint:  "https://" + net.JoinHostPort(host, port),
+		tokenFile: serviceAccountTokenFile,
+		client:    &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+	}
+}
+
+func (p *eksMetadataProviderImpl) isEKS(ctx context.Context) (bool, error) {
+	token, err := ioutil.ReadFile(p.tokenFile)
+	if err != nil {
+		return false, fmt.Errorf("failed reading service account token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+authConfigMapPath, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed getting the aws-auth config map, status: %s", resp.Status)
+	}
+}
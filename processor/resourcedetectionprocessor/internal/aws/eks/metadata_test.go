@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) (*eksMetadataProviderImpl, func()) {
+	ts := httptest.NewServer(handler)
+
+	tokenFile, err := ioutil.TempFile("", "token")
+	require.NoError(t, err)
+	_, err = tokenFile.WriteString("test-token\n")
+	require.NoError(t, err)
+	require.NoError(t, tokenFile.Close())
+
+	p := newEKSMetadataProvider("10.100.0.1", "443")
+	p.endpoint = ts.URL
+	p.tokenFile = tokenFile.Name()
+	return p, func() {
+		ts.Close()
+		os.Remove(tokenFile.Name())
+	}
+}
+
+func TestNewEKSMetadataProvider(t *testing.T) {
+	p := newEKSMetadataProvider("10.100.0.1", "443")
+	assert.Equal(t, "https://10.100.0.1:443", p.endpoint)
+	assert.Equal(t, serviceAccountTokenFile, p.tokenFile)
+}
+
+func TestIsEKS(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		want    bool
+		wantErr bool
+	}{
+		{name: "config map found", status: http.StatusOK, want: true},
+		{name: "config map not found", status: http.StatusNotFound, want: false},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, cleanup := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, authConfigMapPath, r.URL.Path)
+				assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+				w.WriteHeader(tt.status)
+			})
+			defer cleanup()
+
+			got, err := p.isEKS(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsEKSMissingToken(t *testing.T) {
+	p := newEKSMetadataProvider("10.100.0.1", "443")
+	p.tokenFile = "/does/not/exist"
+
+	_, err := p.isEKS(context.Background())
+	assert.Error(t, err)
+}
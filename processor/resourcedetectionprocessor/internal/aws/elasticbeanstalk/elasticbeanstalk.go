@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticbeanstalk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "elastic_beanstalk"
+
+	linuxPath   = "/var/elasticbeanstalk/xray/environment.conf"
+	windowsPath = "C:\\Program Files\\Amazon\\XRay\\environment.conf"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an Elastic Beanstalk detector
+type Detector struct {
+	fs fileSystem
+}
+
+// EbMetaData holds the fields of the environment configuration written by Elastic Beanstalk
+type EbMetaData struct {
+	DeploymentID    int    `json:"deployment_id"`
+	EnvironmentName string `json:"environment_name"`
+	VersionLabel    string `json:"version_label"`
+}
+
+// NewDetector creates a new Elastic Beanstalk detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{fs: &ebFileSystem{}}, nil
+}
+
+// Detect reads the environment configuration Elastic Beanstalk writes on its instances.
+// TODO: Replace all attribute fields and enums with values defined in "conventions" once they exist
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	var conf string
+	if d.fs.IsWindows() {
+		conf = windowsPath
+	} else {
+		conf = linuxPath
+	}
+
+	// Not running on Elastic Beanstalk
+	if !d.fs.Exists(conf) {
+		return res, nil
+	}
+
+	r, err := d.fs.Open(conf)
+	if err != nil {
+		return res, err
+	}
+	defer r.Close()
+
+	ebmd := &EbMetaData{}
+	if err := json.NewDecoder(r).Decode(ebmd); err != nil {
+		return res, fmt.Errorf("failed reading Elastic Beanstalk configuration: %w", err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attr.InsertString("cloud.infrastructure_service", "ElasticBeanstalk")
+	attr.InsertString(conventions.AttributeServiceInstance, strconv.Itoa(ebmd.DeploymentID))
+	attr.InsertString(conventions.AttributeDeploymentEnvironment, ebmd.EnvironmentName)
+	attr.InsertString(conventions.AttributeServiceVersion, ebmd.VersionLabel)
+
+	return res, nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticbeanstalk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const xrayConf = `{"deployment_id":23,"version_label":"env-version-1234","environment_name":"BETA"}`
+
+type mockFileSystem struct {
+	windows  bool
+	exists   bool
+	path     string
+	contents string
+	openErr  error
+}
+
+var _ fileSystem = (*mockFileSystem)(nil)
+
+func (m *mockFileSystem) Open(path string) (io.ReadCloser, error) {
+	if m.openErr != nil {
+		return nil, m.openErr
+	}
+	m.path = path
+	return ioutil.NopCloser(strings.NewReader(m.contents)), nil
+}
+
+func (m *mockFileSystem) Exists(string) bool {
+	return m.exists
+}
+
+func (m *mockFileSystem) IsWindows() bool {
+	return m.windows
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	for _, windows := range []bool{false, true} {
+		fs := &mockFileSystem{windows: windows, exists: true, contents: xrayConf}
+		d := &Detector{fs: fs}
+
+		res, err := d.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"cloud.provider":               "aws",
+			"cloud.infrastructure_service": "ElasticBeanstalk",
+			"service.instance.id":          "23",
+			"deployment.environment":       "BETA",
+			"service.version":              "env-version-1234",
+		}, internal.AttributesToMap(res.Attributes()))
+
+		if windows {
+			assert.Equal(t, windowsPath, fs.path)
+		} else {
+			assert.Equal(t, linuxPath, fs.path)
+		}
+	}
+}
+
+func TestDetectNotOnElasticBeanstalk(t *testing.T) {
+	d := &Detector{fs: &mockFileSystem{}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectErrors(t *testing.T) {
+	d := &Detector{fs: &mockFileSystem{exists: true, openErr: errors.New("permission denied")}}
+	_, err := d.Detect(context.Background())
+	assert.Error(t, err)
+
+	d = &Detector{fs: &mockFileSystem{exists: true, contents: "{"}}
+	_, err = d.Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticbeanstalk
+
+import (
+	"io"
+	"os"
+	"runtime"
+)
+
+type fileSystem interface {
+	Open(name string) (io.ReadCloser, error)
+	Exists(name string) bool
+	IsWindows() bool
+}
+
+type ebFileSystem struct{}
+
+var _ fileSystem = (*ebFileSystem)(nil)
+
+func (*ebFileSystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+func (*ebFileSystem) Exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+func (*ebFileSystem) IsWindows() bool {
+	return runtime.GOOS == "windows"
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "azure"
+
+	// TODO: Replace with the values defined in "conventions" once they exist
+	cloudInfrastructureService = "cloud.infrastructure_service"
+	azureVMName                = "azure.vm.name"
+	azureResourceGroupName     = "azure.resourcegroup.name"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an Azure metadata detector
+type Detector struct {
+	provider azureMetadataProvider
+}
+
+// NewDetector creates a new Azure metadata detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{provider: newAzureMetadataProvider()}, nil
+}
+
+// Detect detects associated resources when running in Azure environment.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	compute, err := d.provider.metadata(ctx)
+	if err != nil {
+		// Not running on Azure, the instance metadata service is not reachable.
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	attr.InsertString(cloudInfrastructureService, "VM")
+	attr.InsertString(conventions.AttributeHostName, compute.Name)
+	attr.InsertString(conventions.AttributeHostID, compute.VMID)
+	attr.InsertString(conventions.AttributeHostType, compute.VMSize)
+	attr.InsertString(conventions.AttributeCloudRegion, compute.Location)
+	attr.InsertString(conventions.AttributeCloudAccount, compute.SubscriptionID)
+	attr.InsertString(azureVMName, compute.Name)
+	attr.InsertString(azureResourceGroupName, compute.ResourceGroupName)
+
+	return res, nil
+}
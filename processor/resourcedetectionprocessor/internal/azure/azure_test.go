@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	compute *computeMetadata
+	err     error
+}
+
+var _ azureMetadataProvider = (*mockProvider)(nil)
+
+func (m *mockProvider) metadata(context.Context) (*computeMetadata, error) {
+	return m.compute, m.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectAzureAvailable(t *testing.T) {
+	d := &Detector{provider: &mockProvider{compute: &computeMetadata{
+		Location:          "eastus",
+		Name:              "vm1",
+		VMID:              "13f56399-bd52-4150-9748-7190aae1ff21",
+		VMSize:            "Standard_D2s_v3",
+		SubscriptionID:    "subscription-id",
+		ResourceGroupName: "rg1",
+	}}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":               "azure",
+		"cloud.infrastructure_service": "VM",
+		"cloud.region":                 "eastus",
+		"cloud.account.id":             "subscription-id",
+		"host.name":                    "vm1",
+		"host.id":                      "13f56399-bd52-4150-9748-7190aae1ff21",
+		"host.type":                    "Standard_D2s_v3",
+		"azure.vm.name":                "vm1",
+		"azure.resourcegroup.name":     "rg1",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectAzureNotAvailable(t *testing.T) {
+	d := &Detector{provider: &mockProvider{err: errors.New("connection refused")}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
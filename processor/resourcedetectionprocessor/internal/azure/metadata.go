@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// Azure Instance Metadata Service (IMDS) endpoint, see
+	// https://docs.microsoft.com/en-us/azure/virtual-machines/windows/instance-metadata-service
+	metadataEndpoint = "http://169.254.169.254/metadata/instance/compute"
+	apiVersion       = "2020-09-01"
+)
+
+// computeMetadata is the Azure IMDS compute metadata response
+type computeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+}
+
+type azureMetadataProvider interface {
+	metadata(ctx context.Context) (*computeMetadata, error)
+}
+
+type azureMetadataProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ azureMetadataProvider = (*azureMetadataProviderImpl)(nil)
+
+func newAzureMetadataProvider() *azureMetadataProviderImpl {
+	return &azureMetadataProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// metadata queries the compute metadata of the virtual machine from the IMDS endpoint.
+func (p *azureMetadataProviderImpl) metadata(ctx context.Context) (*computeMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Metadata", "True")
+	q := req.URL.Query()
+	q.Add("format", "json")
+	q.Add("api-version", apiVersion)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Azure IMDS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query Azure IMDS, status: %s", resp.Status)
+	}
+
+	var compute computeMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&compute); err != nil {
+		return nil, fmt.Errorf("failed to decode Azure IMDS reply: %w", err)
+	}
+	return &compute, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryEndpoint(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "True", r.Header.Get("Metadata"))
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		w.Write([]byte(`{"location": "eastus", "name": "vm1", "vmId": "vm-id", "vmSize": "Standard_D2s_v3",
+			"subscriptionId": "subscription-id", "resourceGroupName": "rg1"}`))
+	}))
+	defer ts.Close()
+
+	provider := newAzureMetadataProvider()
+	provider.endpoint = ts.URL
+
+	compute, err := provider.metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &computeMetadata{
+		Location:          "eastus",
+		Name:              "vm1",
+		VMID:              "vm-id",
+		VMSize:            "Standard_D2s_v3",
+		SubscriptionID:    "subscription-id",
+		ResourceGroupName: "rg1",
+	}, compute)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	provider := newAzureMetadataProvider()
+	provider.endpoint = ts.URL
+
+	_, err := provider.metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{"))
+	}))
+	defer ts.Close()
+
+	provider := newAzureMetadataProvider()
+	provider.endpoint = ts.URL
+
+	_, err := provider.metadata(context.Background())
+	assert.Error(t, err)
+}
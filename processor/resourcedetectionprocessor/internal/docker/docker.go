@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "docker"
+
+	// TODO: Replace with the value defined in "conventions" once it exists
+	osType = "os.type"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Docker metadata detector
+type Detector struct {
+	provider dockerMetadataProvider
+}
+
+// NewDetector creates a new Docker metadata detector
+func NewDetector() (internal.Detector, error) {
+	provider, err := newDockerMetadataProvider(dockerHostFromEnv())
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: provider}, nil
+}
+
+// Detect detects the host name and the operating system of the Docker daemon host.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	info, err := d.provider.info(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed getting Docker daemon information: %w", err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostName, info.Name)
+	attr.InsertString(osType, info.OSType)
+
+	return res, nil
+}
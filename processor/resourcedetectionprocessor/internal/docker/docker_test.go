@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockProvider struct {
+	daemonInfo *daemonInfo
+	err        error
+}
+
+var _ dockerMetadataProvider = (*mockProvider)(nil)
+
+func (m *mockProvider) info(context.Context) (*daemonInfo, error) {
+	return m.daemonInfo, m.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	d := &Detector{provider: &mockProvider{daemonInfo: &daemonInfo{Name: "hostname", OSType: "linux"}}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "hostname",
+		"os.type":   "linux",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	d := &Detector{provider: &mockProvider{err: errors.New("connection refused")}}
+
+	res, err := d.Detect(context.Background())
+	assert.Error(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
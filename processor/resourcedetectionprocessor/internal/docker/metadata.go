@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const (
+	defaultDockerHost = "unix:///var/run/docker.sock"
+	dockerHostEnvVar  = "DOCKER_HOST"
+)
+
+// daemonInfo holds the fields used by the detector from the Docker daemon /info endpoint
+type daemonInfo struct {
+	Name   string `json:"Name"`
+	OSType string `json:"OSType"`
+}
+
+type dockerMetadataProvider interface {
+	info(ctx context.Context) (*daemonInfo, error)
+}
+
+type dockerMetadataProviderImpl struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ dockerMetadataProvider = (*dockerMetadataProviderImpl)(nil)
+
+func dockerHostFromEnv() string {
+	if host := os.Getenv(dockerHostEnvVar); host != "" {
+		return host
+	}
+	return defaultDockerHost
+}
+
+// newDockerMetadataProvider creates a provider querying the Docker daemon listening
+// on the given host, either a unix socket (unix://) or a TCP address (tcp://).
+func newDockerMetadataProvider(host string) (*dockerMetadataProviderImpl, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Docker host %q: %w", host, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &dockerMetadataProviderImpl{baseURL: "http://docker", client: &http.Client{Transport: transport}}, nil
+	case "tcp", "http":
+		return &dockerMetadataProviderImpl{baseURL: "http://" + u.Host, client: &http.Client{}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Docker host scheme %q", u.Scheme)
+	}
+}
+
+func (p *dockerMetadataProviderImpl) info(ctx context.Context) (*daemonInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/info", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query Docker daemon, status: %s", resp.Status)
+	}
+
+	var info daemonInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
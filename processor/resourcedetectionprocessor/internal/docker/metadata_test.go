@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerHostFromEnv(t *testing.T) {
+	os.Unsetenv(dockerHostEnvVar)
+	assert.Equal(t, defaultDockerHost, dockerHostFromEnv())
+
+	os.Setenv(dockerHostEnvVar, "tcp://127.0.0.1:2375")
+	defer os.Unsetenv(dockerHostEnvVar)
+	assert.Equal(t, "tcp://127.0.0.1:2375", dockerHostFromEnv())
+}
+
+func TestNewDockerMetadataProvider(t *testing.T) {
+	p, err := newDockerMetadataProvider("unix:///var/run/docker.sock")
+	require.NoError(t, err)
+	assert.Equal(t, "http://docker", p.baseURL)
+
+	p, err = newDockerMetadataProvider("tcp://127.0.0.1:2375")
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:2375", p.baseURL)
+
+	_, err = newDockerMetadataProvider("npipe:////./pipe/docker_engine")
+	assert.EqualError(t, err, `unsupported Docker host scheme "npipe"`)
+}
+
+func TestInfo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/info", r.URL.Path)
+		w.Write([]byte(`{"Name": "hostname", "OSType": "linux", "Containers": 3}`))
+	}))
+	defer ts.Close()
+
+	p, err := newDockerMetadataProvider("tcp://" + ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	info, err := p.info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &daemonInfo{Name: "hostname", OSType: "linux"}, info)
+}
+
+func TestInfoFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	p, err := newDockerMetadataProvider("tcp://" + ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	_, err = p.info(context.Background())
+	assert.Error(t, err)
+}
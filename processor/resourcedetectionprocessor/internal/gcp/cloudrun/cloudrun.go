@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudrun provides a detector that loads resource information from
+// the environment and the GCP metadata server of Cloud Run services
+package cloudrun
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+const (
+	TypeStr = "cloud_run"
+
+	// Environment variables set by Cloud Run, see
+	// https://cloud.google.com/run/docs/reference/container-contract#env-vars
+	serviceEnvVar       = "K_SERVICE"
+	revisionEnvVar      = "K_REVISION"
+	configurationEnvVar = "K_CONFIGURATION"
+
+	// TODO: Replace with the values defined in "conventions" once they exist
+	cloudInfrastructureService = "cloud.infrastructure_service"
+	faasName                   = "faas.name"
+	faasVersion                = "faas.version"
+	faasID                     = "faas.id"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type metadataClient interface {
+	Get(ctx context.Context, suffix string) (string, error)
+}
+
+// Detector is a Cloud Run detector
+type Detector struct {
+	metadata metadataClient
+}
+
+// NewDetector creates a new Cloud Run detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{metadata: gcp.NewMetadataClient()}, nil
+}
+
+// Detect returns a resource describing the Cloud Run service the collector is running in,
+// or an empty resource when it is not running on Cloud Run.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	service := os.Getenv(serviceEnvVar)
+	if service == "" || os.Getenv(configurationEnvVar) == "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attr.InsertString(cloudInfrastructureService, "CloudRun")
+	attr.InsertString(faasName, service)
+	if revision := os.Getenv(revisionEnvVar); revision != "" {
+		attr.InsertString(faasVersion, revision)
+	}
+
+	var errors []error
+
+	projectID, err := d.metadata.Get(ctx, "project/project-id")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudAccount, projectID)
+	}
+
+	region, err := d.metadata.Get(ctx, "instance/region")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudRegion, gcp.LastPathSegment(region))
+	}
+
+	instanceID, err := d.metadata.Get(ctx, "instance/id")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(faasID, instanceID)
+	}
+
+	return res, componenterror.CombineErrors(errors)
+}
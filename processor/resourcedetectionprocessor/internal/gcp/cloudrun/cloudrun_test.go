@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+// newMetadataServer starts a stand-in for the GCP metadata server, serving the given entries
+func newMetadataServer(entries map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := entries[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(value))
+	}))
+}
+
+func newTestDetector(ts *httptest.Server) *Detector {
+	c := gcp.NewMetadataClient()
+	c.Endpoint = ts.URL + "/"
+	return &Detector{metadata: c}
+}
+
+func setCloudRunEnv() func() {
+	os.Setenv(serviceEnvVar, "my-service")
+	os.Setenv(revisionEnvVar, "my-service-00001-abc")
+	os.Setenv(configurationEnvVar, "my-service")
+	return func() {
+		os.Unsetenv(serviceEnvVar)
+		os.Unsetenv(revisionEnvVar)
+		os.Unsetenv(configurationEnvVar)
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectNotOnCloudRun(t *testing.T) {
+	ts := newMetadataServer(map[string]string{
+		"/project/project-id": "my-project",
+	})
+	defer ts.Close()
+
+	res, err := newTestDetector(ts).Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetect(t *testing.T) {
+	defer setCloudRunEnv()()
+
+	ts := newMetadataServer(map[string]string{
+		"/project/project-id": "my-project",
+		"/instance/region":    "projects/123/regions/us-central1",
+		"/instance/id":        "00bf4bf02d",
+	})
+	defer ts.Close()
+
+	res, err := newTestDetector(ts).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":               "gcp",
+		"cloud.infrastructure_service": "CloudRun",
+		"cloud.account.id":             "my-project",
+		"cloud.region":                 "us-central1",
+		"faas.name":                    "my-service",
+		"faas.version":                 "my-service-00001-abc",
+		"faas.id":                      "00bf4bf02d",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectMetadataError(t *testing.T) {
+	defer setCloudRunEnv()()
+
+	ts := newMetadataServer(map[string]string{
+		"/project/project-id": "my-project",
+	})
+	defer ts.Close()
+
+	res, err := newTestDetector(ts).Detect(context.Background())
+	assert.Error(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":               "gcp",
+		"cloud.infrastructure_service": "CloudRun",
+		"cloud.account.id":             "my-project",
+		"faas.name":                    "my-service",
+		"faas.version":                 "my-service-00001-abc",
+	}, internal.AttributesToMap(res.Attributes()))
+}
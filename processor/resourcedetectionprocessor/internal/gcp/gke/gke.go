@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gke provides a detector that loads resource information from
+// the GCP metadata server of GKE nodes
+package gke
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+const (
+	TypeStr = "gke"
+
+	kubernetesServiceHostEnvVar = "KUBERNETES_SERVICE_HOST"
+
+	// TODO: Replace with the value defined in "conventions" once it exists
+	cloudInfrastructureService = "cloud.infrastructure_service"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type metadataClient interface {
+	Get(ctx context.Context, suffix string) (string, error)
+}
+
+// Detector is a GKE detector
+type Detector struct {
+	metadata metadataClient
+}
+
+// NewDetector creates a new GKE detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{metadata: gcp.NewMetadataClient()}, nil
+}
+
+// Detect returns a resource describing the GKE cluster the collector is running in,
+// or an empty resource when it is not running in Kubernetes or not on GKE.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	// Fail fast if not running in Kubernetes
+	if os.Getenv(kubernetesServiceHostEnvVar) == "" {
+		return res, nil
+	}
+
+	clusterName, err := d.metadata.Get(ctx, "instance/attributes/cluster-name")
+	if err != nil {
+		// Not running on GKE: either the metadata server is not reachable,
+		// or the node doesn't belong to a GKE cluster.
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attr.InsertString(cloudInfrastructureService, "GKE")
+	attr.InsertString(conventions.AttributeK8sCluster, clusterName)
+
+	var errors []error
+
+	projectID, err := d.metadata.Get(ctx, "project/project-id")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudAccount, projectID)
+	}
+
+	zone, err := d.metadata.Get(ctx, "instance/zone")
+	if err != nil {
+		errors = append(errors, err)
+	} else {
+		attr.InsertString(conventions.AttributeCloudZone, gcp.LastPathSegment(zone))
+	}
+
+	return res, componenterror.CombineErrors(errors)
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
+)
+
+// newMetadataServer starts a stand-in for the GCP metadata server, serving the given entries
+func newMetadataServer(entries map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := entries[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(value))
+	}))
+}
+
+func newTestDetector(ts *httptest.Server) *Detector {
+	c := gcp.NewMetadataClient()
+	c.Endpoint = ts.URL + "/"
+	return &Detector{metadata: c}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectNotInKubernetes(t *testing.T) {
+	os.Unsetenv(kubernetesServiceHostEnvVar)
+	ts := newMetadataServer(map[string]string{
+		"/instance/attributes/cluster-name": "my-cluster",
+	})
+	defer ts.Close()
+
+	res, err := newTestDetector(ts).Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetect(t *testing.T) {
+	os.Setenv(kubernetesServiceHostEnvVar, "10.100.0.1")
+	defer os.Unsetenv(kubernetesServiceHostEnvVar)
+
+	tests := []struct {
+		name    string
+		entries map[string]string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "on GKE",
+			entries: map[string]string{
+				"/instance/attributes/cluster-name": "my-cluster",
+				"/project/project-id":               "my-project",
+				"/instance/zone":                    "projects/123/zones/us-central1-a",
+			},
+			want: map[string]interface{}{
+				"cloud.provider":               "gcp",
+				"cloud.infrastructure_service": "GKE",
+				"cloud.account.id":             "my-project",
+				"cloud.zone":                   "us-central1-a",
+				"k8s.cluster.name":             "my-cluster",
+			},
+		},
+		{
+			name: "not on GKE",
+			entries: map[string]string{
+				"/project/project-id": "my-project",
+				"/instance/zone":      "projects/123/zones/us-central1-a",
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "missing entries",
+			entries: map[string]string{
+				"/instance/attributes/cluster-name": "my-cluster",
+			},
+			want: map[string]interface{}{
+				"cloud.provider":               "gcp",
+				"cloud.infrastructure_service": "GKE",
+				"k8s.cluster.name":             "my-cluster",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newMetadataServer(tt.entries)
+			defer ts.Close()
+
+			res, err := newTestDetector(ts).Detect(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
+
+func TestDetectMetadataServerUnreachable(t *testing.T) {
+	os.Setenv(kubernetesServiceHostEnvVar, "10.100.0.1")
+	defer os.Unsetenv(kubernetesServiceHostEnvVar)
+
+	ts := newMetadataServer(nil)
+	d := newTestDetector(ts)
+	ts.Close()
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
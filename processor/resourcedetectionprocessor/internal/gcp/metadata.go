@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcp provides a client of the GCP metadata server shared by the
+// detectors of the GCP compute platforms.
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// GCP metadata server, see
+	// https://cloud.google.com/compute/docs/storing-retrieving-metadata
+	metadataEndpoint = "http://metadata.google.internal/computeMetadata/v1/"
+
+	// metadataHostEnvVar overrides the host of the metadata server, as in the Google Cloud Client Libraries
+	metadataHostEnvVar = "GCE_METADATA_HOST"
+)
+
+// ErrMetadataNotFound is returned when the metadata server doesn't know the requested entry.
+var ErrMetadataNotFound = errors.New("metadata entry not found")
+
+// MetadataClient queries the GCP metadata server.
+type MetadataClient struct {
+	Endpoint string
+	client   *http.Client
+}
+
+// NewMetadataClient creates a client of the metadata server of the current environment.
+func NewMetadataClient() *MetadataClient {
+	endpoint := metadataEndpoint
+	if host := os.Getenv(metadataHostEnvVar); host != "" {
+		endpoint = "http://" + host + "/computeMetadata/v1/"
+	}
+	return &MetadataClient{
+		Endpoint: endpoint,
+		client:   &http.Client{},
+	}
+}
+
+// Get returns the metadata entry with the given suffix, e.g. "project/project-id".
+func (c *MetadataClient) Get(ctx context.Context, suffix string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Endpoint+suffix, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Metadata-Flavor", "Google")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query the GCP metadata server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("failed to get %q: %w", suffix, ErrMetadataNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query the GCP metadata server for %q, status: %s", suffix, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the GCP metadata server reply for %q: %w", suffix, err)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// LastPathSegment returns the last segment of the fully qualified names returned by
+// the metadata server, e.g. "us-central1" for "projects/123/regions/us-central1".
+func LastPathSegment(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetadataClient(t *testing.T) {
+	assert.Equal(t, metadataEndpoint, NewMetadataClient().Endpoint)
+
+	os.Setenv(metadataHostEnvVar, "127.0.0.1:8080")
+	defer os.Unsetenv(metadataHostEnvVar)
+	assert.Equal(t, "http://127.0.0.1:8080/computeMetadata/v1/", NewMetadataClient().Endpoint)
+}
+
+func TestMetadataGet(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		assert.Equal(t, "/project/project-id", r.URL.Path)
+		w.Write([]byte("my-project\n"))
+	}))
+	defer ts.Close()
+
+	c := NewMetadataClient()
+	c.Endpoint = ts.URL + "/"
+
+	projectID, err := c.Get(context.Background(), "project/project-id")
+	require.NoError(t, err)
+	assert.Equal(t, "my-project", projectID)
+}
+
+func TestMetadataGetNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := NewMetadataClient()
+	c.Endpoint = ts.URL + "/"
+
+	_, err := c.Get(context.Background(), "instance/attributes/cluster-name")
+	assert.True(t, errors.Is(err, ErrMetadataNotFound))
+}
+
+func TestMetadataGetFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewMetadataClient()
+	c.Endpoint = ts.URL + "/"
+
+	_, err := c.Get(context.Background(), "project/project-id")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMetadataNotFound))
+}
+
+func TestLastPathSegment(t *testing.T) {
+	assert.Equal(t, "us-central1", LastPathSegment("projects/123/regions/us-central1"))
+	assert.Equal(t, "us-central1-a", LastPathSegment("us-central1-a"))
+	assert.Equal(t, "", LastPathSegment(""))
+}
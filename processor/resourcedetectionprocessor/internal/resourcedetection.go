@@ -44,12 +44,35 @@ func NewProviderFactory(detectors map[DetectorType]DetectorFactory) *ResourcePro
 }
 
 func (f *ResourceProviderFactory) CreateResourceProvider(logger *zap.Logger, timeout time.Duration, detectorTypes ...DetectorType) (*ResourceProvider, error) {
+	return f.CreateResourceProviderWithSettings(logger, ProviderSettings{Timeout: timeout}, detectorTypes...)
+}
+
+// ProviderSettings holds the settings of a ResourceProvider.
+type ProviderSettings struct {
+	// Timeout is the maximum amount of time a detection can take.
+	Timeout time.Duration
+	// RefreshInterval is the interval after which the resource is detected again.
+	// The resource is only detected once if it is zero.
+	RefreshInterval time.Duration
+	// Attributes is an allow-list of the attributes kept from each detector.
+	// Detectors without an entry keep all of their attributes.
+	Attributes map[DetectorType][]string
+}
+
+func (f *ResourceProviderFactory) CreateResourceProviderWithSettings(logger *zap.Logger, settings ProviderSettings, detectorTypes ...DetectorType) (*ResourceProvider, error) {
 	detectors, err := f.getDetectors(detectorTypes)
 	if err != nil {
 		return nil, err
 	}
 
-	provider := NewResourceProvider(logger, timeout, detectors...)
+	for i, detectorType := range detectorTypes {
+		if attributes, ok := settings.Attributes[detectorType]; ok {
+			detectors[i] = newFilteringDetector(detectors[i], attributes)
+		}
+	}
+
+	provider := NewResourceProvider(logger, settings.Timeout, detectors...)
+	provider.refreshInterval = settings.RefreshInterval
 	return provider, nil
 }
 
@@ -72,12 +95,50 @@ func (f *ResourceProviderFactory) getDetectors(detectorTypes []DetectorType) ([]
 	return detectors, nil
 }
 
+// filteringDetector only keeps the allowed attributes of the resource detected
+// by the wrapped detector.
+type filteringDetector struct {
+	detector Detector
+	allowed  map[string]struct{}
+}
+
+func newFilteringDetector(detector Detector, attributes []string) Detector {
+	allowed := make(map[string]struct{}, len(attributes))
+	for _, attribute := range attributes {
+		allowed[attribute] = struct{}{}
+	}
+	return &filteringDetector{detector: detector, allowed: allowed}
+}
+
+func (d *filteringDetector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res, err := d.detector.Detect(ctx)
+	if err != nil || res.IsNil() {
+		return res, err
+	}
+
+	attrs := res.Attributes()
+	var filtered []string
+	attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+		if _, ok := d.allowed[k]; !ok {
+			filtered = append(filtered, k)
+		}
+	})
+	for _, k := range filtered {
+		attrs.Delete(k)
+	}
+	return res, nil
+}
+
 type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
+	refreshInterval  time.Duration
 	detectors        []Detector
 	detectedResource *resourceResult
-	once             sync.Once
+	detectedAt       time.Time
+	// detecting is closed once the detection in progress, if any, completes
+	detecting chan struct{}
+	mu        sync.Mutex
 }
 
 type resourceResult struct {
@@ -93,19 +154,63 @@ func NewResourceProvider(logger *zap.Logger, timeout time.Duration, detectors ..
 	}
 }
 
+// Get returns the detected resource. The resource is detected on the first call, and
+// detected again once the refresh interval has elapsed, if any. When detecting the
+// resource again fails, the previously detected resource is kept. The detection runs
+// without holding the lock: callers get the previously detected resource while it's
+// being refreshed, and only wait for the first detection.
 func (p *ResourceProvider) Get(ctx context.Context) (pdata.Resource, error) {
-	p.once.Do(func() {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, p.timeout)
-		defer cancel()
-		p.detectResource(ctx)
-	})
+	p.mu.Lock()
+	for {
+		if p.detectedResource != nil && (p.detecting != nil || !p.expired()) {
+			result := p.detectedResource
+			p.mu.Unlock()
+			return result.resource, result.err
+		}
+		if p.detecting == nil {
+			break
+		}
+
+		detecting := p.detecting
+		p.mu.Unlock()
+		select {
+		case <-detecting:
+		case <-ctx.Done():
+			return pdata.NewResource(), ctx.Err()
+		}
+		p.mu.Lock()
+	}
+	detecting := make(chan struct{})
+	p.detecting = detecting
+	p.mu.Unlock()
+
+	detectCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	result := p.detectResource(detectCtx)
+	cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.detecting = nil
+	close(detecting)
+	p.detectedAt = time.Now()
+
+	if result.err != nil && p.detectedResource != nil && p.detectedResource.err == nil {
+		p.logger.Warn("failed refreshing resource information, keeping the previous one", zap.Error(result.err))
+		return p.detectedResource.resource, nil
+	}
 
+	p.detectedResource = result
 	return p.detectedResource.resource, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// expired returns whether the detected resource must be detected again.
+// The caller is expected to hold the lock.
+func (p *ResourceProvider) expired() bool {
+	return p.refreshInterval > 0 && time.Since(p.detectedAt) >= p.refreshInterval
+}
+
+func (p *ResourceProvider) detectResource(ctx context.Context) *resourceResult {
+	result := &resourceResult{}
 
 	res := pdata.NewResource()
 	res.InitEmpty()
@@ -115,8 +220,8 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 	for _, detector := range p.detectors {
 		r, err := detector.Detect(ctx)
 		if err != nil {
-			p.detectedResource.err = err
-			return
+			result.err = err
+			return result
 		}
 
 		MergeResource(res, r, false)
@@ -124,7 +229,8 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 
 	p.logger.Info("detected resource information", zap.Any("resource", AttributesToMap(res.Attributes())))
 
-	p.detectedResource.resource = res
+	result.resource = res
+	return result
 }
 
 func AttributesToMap(am pdata.AttributeMap) map[string]interface{} {
@@ -163,7 +269,26 @@ func getSerializableArray(inArr pdata.AnyValueArray) []interface{} {
 	return outArr
 }
 
+// MergePolicy defines how a detected attribute is merged with an existing attribute of the same name.
+type MergePolicy string
+
+const (
+	// MergePolicyKeep keeps the existing attribute.
+	MergePolicyKeep MergePolicy = "keep"
+	// MergePolicyOverride overrides the existing attribute.
+	MergePolicyOverride MergePolicy = "override"
+	// MergePolicySkipIfEmpty overrides the existing attribute, unless the detected value is empty.
+	MergePolicySkipIfEmpty MergePolicy = "skip_if_empty"
+)
+
 func MergeResource(to, from pdata.Resource, overrideTo bool) {
+	MergeResourceWithPolicies(to, from, overrideTo, nil)
+}
+
+// MergeResourceWithPolicies merges the attributes of from into to. Attributes
+// having a merge policy are merged according to it, the other attributes
+// override existing ones if overrideTo is set.
+func MergeResourceWithPolicies(to, from pdata.Resource, overrideTo bool, policies map[string]MergePolicy) {
 	if IsEmptyResource(from) {
 		return
 	}
@@ -174,14 +299,38 @@ func MergeResource(to, from pdata.Resource, overrideTo bool) {
 
 	toAttr := to.Attributes()
 	from.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
-		if overrideTo {
+		policy, ok := policies[k]
+		if !ok {
+			policy = MergePolicyKeep
+			if overrideTo {
+				policy = MergePolicyOverride
+			}
+		}
+
+		switch policy {
+		case MergePolicyOverride:
 			toAttr.Upsert(k, v)
-		} else {
+		case MergePolicySkipIfEmpty:
+			if !isEmptyAttribute(v) {
+				toAttr.Upsert(k, v)
+			}
+		default:
 			toAttr.Insert(k, v)
 		}
 	})
 }
 
+func isEmptyAttribute(v pdata.AttributeValue) bool {
+	switch v.Type() {
+	case pdata.AttributeValueNULL:
+		return true
+	case pdata.AttributeValueSTRING:
+		return v.StringVal() == ""
+	default:
+		return false
+	}
+}
+
 func IsEmptyResource(res pdata.Resource) bool {
 	return res.IsNil() || res.Attributes().Len() == 0
 }
@@ -164,6 +164,77 @@ func TestMergeResource(t *testing.T) {
 	}
 }
 
+func TestMergeResourceWithPolicies(t *testing.T) {
+	to := NewResource(map[string]interface{}{"keep": "1", "override": "1", "skip": "1", "default": "1", "empty": "1"})
+	from := NewResource(map[string]interface{}{"keep": "2", "override": "2", "skip": "", "default": "2", "empty": "2", "new": "2"})
+	policies := map[string]MergePolicy{
+		"keep":     MergePolicyKeep,
+		"override": MergePolicyOverride,
+		"skip":     MergePolicySkipIfEmpty,
+		"empty":    MergePolicySkipIfEmpty,
+	}
+
+	MergeResourceWithPolicies(to, from, false, policies)
+	assert.Equal(t, map[string]interface{}{
+		"keep":     "1",
+		"override": "2",
+		"skip":     "1",
+		"default":  "1",
+		"empty":    "2",
+		"new":      "2",
+	}, AttributesToMap(to.Attributes()))
+}
+
+func TestDetectResource_AttributesAllowList(t *testing.T) {
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(NewResource(map[string]interface{}{"cloud.region": "us-west-2", "host.id": "i-1"}), nil)
+	md2 := &MockDetector{}
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"host.name": "host"}), nil)
+
+	f := NewProviderFactory(map[DetectorType]DetectorFactory{
+		"md1": func() (Detector, error) { return md1, nil },
+		"md2": func() (Detector, error) { return md2, nil },
+	})
+	p, err := f.CreateResourceProviderWithSettings(zap.NewNop(), ProviderSettings{
+		Timeout:    time.Second,
+		Attributes: map[DetectorType][]string{"md1": {"cloud.region"}},
+	}, "md1", "md2")
+	require.NoError(t, err)
+
+	res, err := p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.region": "us-west-2",
+		"host.name":    "host",
+	}, AttributesToMap(res.Attributes()))
+}
+
+func TestDetectResource_Refresh(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"host.id": "i-1"}), nil).Once()
+	md.On("Detect").Return(pdata.NewResource(), errors.New("err1")).Once()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"host.id": "i-2"}), nil).Once()
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, md)
+	p.refreshInterval = time.Millisecond
+
+	res, err := p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.id": "i-1"}, AttributesToMap(res.Attributes()))
+
+	// failing refresh keeps the previous resource
+	time.Sleep(2 * time.Millisecond)
+	res, err = p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.id": "i-1"}, AttributesToMap(res.Attributes()))
+
+	time.Sleep(2 * time.Millisecond)
+	res, err = p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.id": "i-2"}, AttributesToMap(res.Attributes()))
+	md.AssertNumberOfCalls(t, "Detect", 3)
+}
+
 type MockParallelDetector struct {
 	mock.Mock
 	ch chan struct{}
@@ -219,6 +290,42 @@ func TestDetectResource_Parallel(t *testing.T) {
 	md2.AssertNumberOfCalls(t, "Detect", 1)
 }
 
+// TestDetectResource_RefreshDoesntBlock validates that the previous resource is returned
+// while it's being detected again
+func TestDetectResource_RefreshDoesntBlock(t *testing.T) {
+	md := NewMockParallelDetector()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"host.id": "i-1"}), nil).Once()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"host.id": "i-2"}), nil).Once()
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, md)
+	p.refreshInterval = time.Millisecond
+
+	go func() { md.ch <- struct{}{} }()
+	_, err := p.Get(context.Background())
+	require.NoError(t, err)
+
+	// start a refresh, blocked in the detector
+	time.Sleep(2 * time.Millisecond)
+	refreshed := make(chan pdata.Resource)
+	go func() {
+		res, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		refreshed <- res
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	// test
+	res, err := p.Get(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"host.id": "i-1"}, AttributesToMap(res.Attributes()))
+
+	md.ch <- struct{}{}
+	assert.Equal(t, map[string]interface{}{"host.id": "i-2"}, AttributesToMap((<-refreshed).Attributes()))
+	md.AssertNumberOfCalls(t, "Detect", 2)
+}
+
 func TestAttributesToMap(t *testing.T) {
 	m := map[string]interface{}{
 		"str":    "a",
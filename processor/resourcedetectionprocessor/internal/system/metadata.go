@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+)
+
+type systemMetadata interface {
+	// Hostname returns the OS hostname
+	Hostname() (string, error)
+
+	// FQDN returns the fully qualified domain name
+	FQDN() (string, error)
+
+	// OSType returns the host operating system
+	OSType() string
+}
+
+type systemMetadataImpl struct{}
+
+var _ systemMetadata = (*systemMetadataImpl)(nil)
+
+func (*systemMetadataImpl) Hostname() (string, error) {
+	return os.Hostname()
+}
+
+// FQDN resolves the addresses of the hostname and returns the first name
+// the addresses resolve back to.
+func (m *systemMetadataImpl) FQDN() (string, error) {
+	hostname, err := m.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	addrs, err := net.LookupHost(hostname)
+	if err != nil {
+		return "", err
+	}
+	for _, addr := range addrs {
+		names, err := net.LookupAddr(addr)
+		if err != nil || len(names) == 0 {
+			continue
+		}
+		return strings.TrimSuffix(names[0], "."), nil
+	}
+	return "", fmt.Errorf("failed resolving the fully qualified domain name of %q", hostname)
+}
+
+func (*systemMetadataImpl) OSType() string {
+	return runtime.GOOS
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "system"
+
+	// TODO: Replace with the value defined in "conventions" once it exists
+	osType = "os.type"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a system metadata detector
+type Detector struct {
+	provider systemMetadata
+}
+
+// NewDetector creates a new system metadata detector
+func NewDetector() (internal.Detector, error) {
+	return &Detector{provider: &systemMetadataImpl{}}, nil
+}
+
+// Detect detects the hostname, the fully qualified domain name and the operating system of the host.
+// The host name is the FQDN when it can be resolved, and the hostname otherwise.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	hostname, err := d.provider.Hostname()
+	if err != nil {
+		return res, fmt.Errorf("failed getting hostname: %w", err)
+	}
+
+	name := hostname
+	if fqdn, err := d.provider.FQDN(); err == nil {
+		name = fqdn
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostHostname, hostname)
+	attr.InsertString(conventions.AttributeHostName, name)
+	attr.InsertString(osType, d.provider.OSType())
+
+	return res, nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	hostname    string
+	hostnameErr error
+	fqdn        string
+	fqdnErr     error
+}
+
+var _ systemMetadata = (*mockMetadata)(nil)
+
+func (m *mockMetadata) Hostname() (string, error) {
+	return m.hostname, m.hostnameErr
+}
+
+func (m *mockMetadata) FQDN() (string, error) {
+	return m.fqdn, m.fqdnErr
+}
+
+func (m *mockMetadata) OSType() string {
+	return "linux"
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetectFQDNAvailable(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{hostname: "host", fqdn: "host.example.com"}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.hostname": "host",
+		"host.name":     "host.example.com",
+		"os.type":       "linux",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectFQDNNotAvailable(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{hostname: "host", fqdnErr: errors.New("no such host")}}
+
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.hostname": "host",
+		"host.name":     "host",
+		"os.type":       "linux",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectError(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{hostnameErr: errors.New("err")}}
+
+	res, err := d.Detect(context.Background())
+	assert.Error(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestSystemMetadata(t *testing.T) {
+	m := &systemMetadataImpl{}
+	hostname, err := m.Hostname()
+	require.NoError(t, err)
+	assert.NotEmpty(t, hostname)
+	assert.Equal(t, runtime.GOOS, m.OSType())
+}
@@ -16,64 +16,115 @@ package resourcedetectionprocessor
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 )
 
 type resourceDetectionProcessor struct {
-	provider *internal.ResourceProvider
+	logger          *zap.Logger
+	provider        *internal.ResourceProvider
+	override        bool
+	policies        map[string]internal.MergePolicy
+	refreshInterval time.Duration
+	done            chan struct{}
+	shutdownOnce    sync.Once
+
+	mu       sync.RWMutex
 	resource pdata.Resource
-	override bool
 }
 
 // Start is invoked during service startup.
 func (rdp *resourceDetectionProcessor) Start(ctx context.Context, host component.Host) error {
-	var err error
-	rdp.resource, err = rdp.provider.Get(ctx)
-	return err
+	res, err := rdp.provider.Get(ctx)
+	if err != nil {
+		return err
+	}
+	rdp.setResource(res)
+
+	if rdp.refreshInterval > 0 {
+		go rdp.refreshLoop()
+	}
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (rdp *resourceDetectionProcessor) Shutdown(context.Context) error {
+	rdp.shutdownOnce.Do(func() { close(rdp.done) })
+	return nil
+}
+
+// refreshLoop periodically gets the resource from the provider, which detects it
+// again once the refresh interval has elapsed.
+func (rdp *resourceDetectionProcessor) refreshLoop() {
+	ticker := time.NewTicker(rdp.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			res, err := rdp.provider.Get(context.Background())
+			if err != nil {
+				rdp.logger.Warn("failed refreshing resource information", zap.Error(err))
+				continue
+			}
+			rdp.setResource(res)
+		case <-rdp.done:
+			return
+		}
+	}
+}
+
+func (rdp *resourceDetectionProcessor) setResource(res pdata.Resource) {
+	rdp.mu.Lock()
+	rdp.resource = res
+	rdp.mu.Unlock()
+}
+
+func (rdp *resourceDetectionProcessor) getResource() pdata.Resource {
+	rdp.mu.RLock()
+	defer rdp.mu.RUnlock()
+	return rdp.resource
+}
+
+func (rdp *resourceDetectionProcessor) mergeResource(res pdata.Resource, detected pdata.Resource) {
+	if res.IsNil() {
+		res.InitEmpty()
+	}
+	internal.MergeResourceWithPolicies(res, detected, rdp.override, rdp.policies)
 }
 
 // ProcessTraces implements the TraceProcessor interface
 func (rdp *resourceDetectionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	detected := rdp.getResource()
 	rs := td.ResourceSpans()
 	for i := 0; i < rs.Len(); i++ {
-		res := rs.At(i).Resource()
-		if res.IsNil() {
-			res.InitEmpty()
-		}
-
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		rdp.mergeResource(rs.At(i).Resource(), detected)
 	}
 	return td, nil
 }
 
 // ProcessMetrics implements the MetricsProcessor interface
 func (rdp *resourceDetectionProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	detected := rdp.getResource()
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
-		res := rm.At(i).Resource()
-		if res.IsNil() {
-			res.InitEmpty()
-		}
-
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		rdp.mergeResource(rm.At(i).Resource(), detected)
 	}
 	return md, nil
 }
 
 // ProcessLogs implements the LogsProcessor interface
 func (rdp *resourceDetectionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	detected := rdp.getResource()
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
-		res := rls.At(i).Resource()
-		if res.IsNil() {
-			res.InitEmpty()
-		}
-
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		rdp.mergeResource(rls.At(i).Resource(), detected)
 	}
 	return ld, nil
 }
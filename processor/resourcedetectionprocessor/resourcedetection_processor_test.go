@@ -51,6 +51,7 @@ func TestResourceProcessor(t *testing.T) {
 		name               string
 		detectorKeys       []string
 		override           bool
+		mergePolicies      []AttributeMergePolicy
 		sourceResource     pdata.Resource
 		detectedResource   pdata.Resource
 		detectedError      error
@@ -106,6 +107,29 @@ func TestResourceProcessor(t *testing.T) {
 				"host.name":        "k8s-node",
 			}),
 		},
+		{
+			name:     "Resource is merged with policies",
+			override: false,
+			mergePolicies: []AttributeMergePolicy{
+				{Attribute: "cloud.zone", Policy: "override"},
+				{Attribute: "host.name", Policy: "skip_if_empty"},
+			},
+			sourceResource: internal.NewResource(map[string]interface{}{
+				"cloud.zone":       "will-be-overridden",
+				"host.name":        "original-node",
+				"k8s.cluster.name": "original-cluster",
+			}),
+			detectedResource: internal.NewResource(map[string]interface{}{
+				"cloud.zone":       "zone-1",
+				"host.name":        "",
+				"k8s.cluster.name": "will-be-ignored",
+			}),
+			expectedResource: internal.NewResource(map[string]interface{}{
+				"cloud.zone":       "zone-1",
+				"host.name":        "original-node",
+				"k8s.cluster.name": "original-cluster",
+			}),
+		},
 		{
 			name: "Empty detected resource",
 			sourceResource: internal.NewResource(map[string]interface{}{
@@ -148,6 +172,11 @@ func TestResourceProcessor(t *testing.T) {
 			detectedError:      errors.New("err1"),
 			expectedStartError: "err1",
 		},
+		{
+			name:             "Invalid merge policy",
+			mergePolicies:    []AttributeMergePolicy{{Attribute: "cloud.zone", Policy: "merge"}},
+			expectedNewError: `invalid merge policy "merge" for attribute "cloud.zone"`,
+		},
 		{
 			name:             "Invalid detector key",
 			detectorKeys:     []string{"invalid-key"},
@@ -170,7 +199,7 @@ func TestResourceProcessor(t *testing.T) {
 				tt.detectorKeys = []string{"mock"}
 			}
 
-			cfg := &Config{Override: tt.override, Detectors: tt.detectorKeys, Timeout: time.Second}
+			cfg := &Config{Override: tt.override, MergePolicies: tt.mergePolicies, Detectors: tt.detectorKeys, Timeout: time.Second}
 
 			// Test trace consuner
 			ttn := new(consumertest.TracesSink)
@@ -276,6 +305,40 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[string]*internal.ResourceProvider{}}
+
+	md := &MockDetector{}
+	md.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.id": "i-1"}), nil).Once()
+	md.On("Detect").Return(internal.NewResource(map[string]interface{}{"host.id": "i-2"}), nil)
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func() (internal.Detector, error) {
+			return md, nil
+		}})
+
+	cfg := &Config{Override: true, Detectors: []string{"mock"}, Timeout: time.Second, RefreshInterval: 10 * time.Millisecond}
+	ttn := new(consumertest.TracesSink)
+	rtp, err := factory.createTraceProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, ttn)
+	require.NoError(t, err)
+
+	require.NoError(t, rtp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rtp.Shutdown(context.Background())) }()
+
+	hostID := func() string {
+		td := pdata.NewTraces()
+		td.ResourceSpans().Resize(1)
+		require.NoError(t, rtp.ConsumeTraces(context.Background(), td))
+		traces := ttn.AllTraces()
+		v, _ := traces[len(traces)-1].ResourceSpans().At(0).Resource().Attributes().Get("host.id")
+		return v.StringVal()
+	}
+
+	assert.Equal(t, "i-1", hostID())
+	assert.Eventually(t, func() bool {
+		return hostID() == "i-2"
+	}, time.Second, 10*time.Millisecond)
+}
+
 func oCensusResource(res pdata.Resource) *resourcepb.Resource {
 	if res.IsNil() {
 		return &resourcepb.Resource{}
@@ -354,3 +417,10 @@ func BenchmarkConsumeLogsAll(b *testing.B) {
 	cfg := &Config{Override: true, Detectors: []string{env.TypeStr, gce.TypeStr}}
 	benchmarkConsumeLogs(b, cfg)
 }
+
+func TestResourceProcessorShutdownTwice(t *testing.T) {
+	rdp := &resourceDetectionProcessor{done: make(chan struct{})}
+
+	assert.NoError(t, rdp.Shutdown(context.Background()))
+	assert.NoError(t, rdp.Shutdown(context.Background()))
+}
@@ -18,6 +18,17 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
+// AttributeSource defines where the routing value is read from.
+type AttributeSource string
+
+const (
+	// ContextAttributeSource reads the routing value from the incoming context, such as the HTTP/gRPC headers
+	// of the original request.
+	ContextAttributeSource AttributeSource = "context"
+	// ResourceAttributeSource reads the routing value from the resource attributes of the data.
+	ResourceAttributeSource AttributeSource = "resource"
+)
+
 // Config defines configuration for the Routing processor.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
@@ -34,6 +45,13 @@ type Config struct {
 	// Required.
 	FromAttribute string `mapstructure:"from_attribute"`
 
+	// AttributeSource defines where the attribute specified under FromAttribute is read from: either from the
+	// incoming context ("context") or from the resource attributes of the data ("resource"). When reading from
+	// the resource, each batch is split so that each resource is routed according to its own attribute value,
+	// allowing this processor to be placed after processors creating a new context, like batch.
+	// Optional, defaults to "context".
+	AttributeSource AttributeSource `mapstructure:"attribute_source"`
+
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
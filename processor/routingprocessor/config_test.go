@@ -52,6 +52,7 @@ func TestLoadConfig(t *testing.T) {
 			},
 			DefaultExporters: []string{"otlp"},
 			FromAttribute:    "X-Tenant",
+			AttributeSource:  ContextAttributeSource,
 			Table: []RoutingTableItem{
 				{
 					Value:     "acme",
@@ -63,4 +64,22 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		})
+
+	parsed = cfg.Processors["routing/resource"]
+	assert.Equal(t, parsed,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				NameVal: "routing/resource",
+				TypeVal: "routing",
+			},
+			DefaultExporters: []string{"otlp"},
+			FromAttribute:    "tenant",
+			AttributeSource:  ResourceAttributeSource,
+			Table: []RoutingTableItem{
+				{
+					Value:     "acme",
+					Exporters: []string{"otlp/acme"},
+				},
+			},
+		})
 }
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -34,6 +35,8 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor),
 	)
 }
 
@@ -43,13 +46,27 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		AttributeSource: ContextAttributeSource,
 	}
 }
 
 func createTraceProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.TracesConsumer) (component.TracesProcessor, error) {
-	_, ok := nextConsumer.(component.Processor)
-	if ok {
-		params.Logger.Warn("another processor has been defined after the routing processor: it will NOT receive any data!")
+	warnIfNextIsProcessor(params.Logger, nextConsumer)
+	return newProcessor(params.Logger, cfg, configmodels.TracesDataType)
+}
+
+func createMetricsProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.MetricsConsumer) (component.MetricsProcessor, error) {
+	warnIfNextIsProcessor(params.Logger, nextConsumer)
+	return newProcessor(params.Logger, cfg, configmodels.MetricsDataType)
+}
+
+func createLogsProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	warnIfNextIsProcessor(params.Logger, nextConsumer)
+	return newProcessor(params.Logger, cfg, configmodels.LogsDataType)
+}
+
+func warnIfNextIsProcessor(logger *zap.Logger, nextConsumer interface{}) {
+	if _, ok := nextConsumer.(component.Processor); ok {
+		logger.Warn("another processor has been defined after the routing processor: it will NOT receive any data!")
 	}
-	return newProcessor(params.Logger, cfg)
 }
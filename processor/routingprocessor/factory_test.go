@@ -56,6 +56,36 @@ func TestProcessorGetsCreatedWithValidConfiguration(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestMetricsAndLogsProcessorsGetCreatedWithValidConfiguration(t *testing.T) {
+	// prepare
+	factory := NewFactory()
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "routing",
+			TypeVal: "routing",
+		},
+		DefaultExporters: []string{"otlp"},
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+
+	// test
+	metricsExp, metricsErr := factory.CreateMetricsProcessor(context.Background(), creationParams, cfg, consumertest.NewMetricsNop())
+	logsExp, logsErr := factory.CreateLogsProcessor(context.Background(), creationParams, cfg, consumertest.NewLogsNop())
+
+	// verify
+	assert.NoError(t, metricsErr)
+	assert.NotNil(t, metricsExp)
+	assert.NoError(t, logsErr)
+	assert.NotNil(t, logsExp)
+}
+
 func TestFailOnEmptyConfiguration(t *testing.T) {
 	// prepare
 	factory := NewFactory()
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
@@ -32,20 +33,33 @@ var (
 	errNoTableItems           = errors.New("the routing table is empty")
 	errNoMissingFromAttribute = errors.New("the FromAttribute property is empty")
 	errExporterNotFound       = errors.New("exporter not found")
+	errExporterNotSupported   = errors.New("exporter doesn't support the pipeline's data type")
+	errInvalidAttributeSource = errors.New("the AttributeSource property is invalid")
 )
 
 var _ component.TracesProcessor = (*processorImp)(nil)
+var _ component.MetricsProcessor = (*processorImp)(nil)
+var _ component.LogsProcessor = (*processorImp)(nil)
 
 type processorImp struct {
 	logger *zap.Logger
 	config Config
+	// dataType is the type of the pipeline this processor is part of, determining the exporters
+	// it routes the data to.
+	dataType configmodels.DataType
 
 	defaultTraceExporters []component.TracesExporter
 	traceExporters        map[string][]component.TracesExporter
+
+	defaultMetricsExporters []component.MetricsExporter
+	metricsExporters        map[string][]component.MetricsExporter
+
+	defaultLogsExporters []component.LogsExporter
+	logsExporters        map[string][]component.LogsExporter
 }
 
 // Crete new processor
-func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp, error) {
+func newProcessor(logger *zap.Logger, cfg configmodels.Exporter, dataType configmodels.DataType) (*processorImp, error) {
 	logger.Info("building processor")
 
 	oCfg := cfg.(*Config)
@@ -67,33 +81,55 @@ func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp,
 		return nil, fmt.Errorf("invalid attribute to read the route's value from: %w", errNoMissingFromAttribute)
 	}
 
+	switch oCfg.AttributeSource {
+	case "", ContextAttributeSource, ResourceAttributeSource:
+	default:
+		return nil, fmt.Errorf("invalid attribute source %q, expected %q or %q: %w",
+			oCfg.AttributeSource, ContextAttributeSource, ResourceAttributeSource, errInvalidAttributeSource)
+	}
+
 	return &processorImp{
-		logger:         logger,
-		config:         *oCfg,
-		traceExporters: make(map[string][]component.TracesExporter),
+		logger:           logger,
+		config:           *oCfg,
+		dataType:         dataType,
+		traceExporters:   make(map[string][]component.TracesExporter),
+		metricsExporters: make(map[string][]component.MetricsExporter),
+		logsExporters:    make(map[string][]component.LogsExporter),
 	}, nil
 }
 
 func (e *processorImp) Start(_ context.Context, host component.Host) error {
-	// first, let's build a map of exporter names with the exporter instances
+	// first, let's build a map of exporter names with the exporter instances for this pipeline's data type
 	source := host.GetExporters()
-	availableExporters := map[string]component.TracesExporter{}
-	for k, exp := range source[configmodels.TracesDataType] {
-		traceExp, ok := exp.(component.TracesExporter)
-		if !ok {
-			return fmt.Errorf("the exporter %q isn't a trace exporter", k.Name())
+	availableExporters := map[string]component.Exporter{}
+	for k, exp := range source[e.dataType] {
+		if !isExporterOfType(exp, e.dataType) {
+			return fmt.Errorf("the exporter %q isn't a %s exporter", k.Name(), e.dataType)
+		}
+		availableExporters[k.Name()] = exp
+	}
+
+	// the exporters that exist for other data types only, to tell them apart from the missing ones
+	otherExporters := map[string]configmodels.DataType{}
+	for dataType, exporters := range source {
+		if dataType == e.dataType {
+			continue
+		}
+		for k := range exporters {
+			if _, ok := availableExporters[k.Name()]; !ok {
+				otherExporters[k.Name()] = dataType
+			}
 		}
-		availableExporters[k.Name()] = traceExp
 	}
 
 	// default exporters
-	if err := e.registerExportersForDefaultRoute(availableExporters, e.config.DefaultExporters); err != nil {
+	if err := e.registerExportersForDefaultRoute(availableExporters, otherExporters, e.config.DefaultExporters); err != nil {
 		return err
 	}
 
 	// exporters for each defined value
 	for _, item := range e.config.Table {
-		if err := e.registerExportersForRoute(item.Value, availableExporters, item.Exporters); err != nil {
+		if err := e.registerExportersForRoute(item.Value, availableExporters, otherExporters, item.Exporters); err != nil {
 			return err
 		}
 	}
@@ -101,35 +137,82 @@ func (e *processorImp) Start(_ context.Context, host component.Host) error {
 	return nil
 }
 
-func (e *processorImp) registerExportersForDefaultRoute(available map[string]component.TracesExporter, requested []string) error {
+func (e *processorImp) registerExportersForDefaultRoute(available map[string]component.Exporter, others map[string]configmodels.DataType, requested []string) error {
 	for _, exp := range requested {
-		v, ok := available[exp]
-		if !ok {
-			return fmt.Errorf("error registering default exporter %q: %w", exp, errExporterNotFound)
+		v, err := e.findExporter(available, others, exp)
+		if err != nil {
+			return fmt.Errorf("error registering default exporter %q: %w", exp, err)
+		}
+
+		switch e.dataType {
+		case configmodels.TracesDataType:
+			e.defaultTraceExporters = append(e.defaultTraceExporters, v.(component.TracesExporter))
+		case configmodels.MetricsDataType:
+			e.defaultMetricsExporters = append(e.defaultMetricsExporters, v.(component.MetricsExporter))
+		case configmodels.LogsDataType:
+			e.defaultLogsExporters = append(e.defaultLogsExporters, v.(component.LogsExporter))
 		}
-		e.defaultTraceExporters = append(e.defaultTraceExporters, v)
 	}
 
 	return nil
 }
 
-func (e *processorImp) registerExportersForRoute(route string, available map[string]component.TracesExporter, requested []string) error {
+func (e *processorImp) registerExportersForRoute(route string, available map[string]component.Exporter, others map[string]configmodels.DataType, requested []string) error {
 	for _, exp := range requested {
-		v, ok := available[exp]
-		if !ok {
-			return fmt.Errorf("error registering route %q for exporter %q: %w", route, exp, errExporterNotFound)
+		v, err := e.findExporter(available, others, exp)
+		if err != nil {
+			return fmt.Errorf("error registering route %q for exporter %q: %w", route, exp, err)
+		}
+
+		switch e.dataType {
+		case configmodels.TracesDataType:
+			e.traceExporters[route] = append(e.traceExporters[route], v.(component.TracesExporter))
+		case configmodels.MetricsDataType:
+			e.metricsExporters[route] = append(e.metricsExporters[route], v.(component.MetricsExporter))
+		case configmodels.LogsDataType:
+			e.logsExporters[route] = append(e.logsExporters[route], v.(component.LogsExporter))
 		}
-		e.traceExporters[route] = append(e.traceExporters[route], v)
 	}
 
 	return nil
 }
 
+func (e *processorImp) findExporter(available map[string]component.Exporter, others map[string]configmodels.DataType, name string) (component.Exporter, error) {
+	if exp, ok := available[name]; ok {
+		return exp, nil
+	}
+
+	if dataType, ok := others[name]; ok {
+		return nil, fmt.Errorf("%w: the exporter is only part of %s pipelines, not %s", errExporterNotSupported, dataType, e.dataType)
+	}
+
+	return nil, errExporterNotFound
+}
+
+func isExporterOfType(exp component.Exporter, dataType configmodels.DataType) bool {
+	switch dataType {
+	case configmodels.TracesDataType:
+		_, ok := exp.(component.TracesExporter)
+		return ok
+	case configmodels.MetricsDataType:
+		_, ok := exp.(component.MetricsExporter)
+		return ok
+	case configmodels.LogsDataType:
+		_, ok := exp.(component.LogsExporter)
+		return ok
+	}
+	return false
+}
+
 func (e *processorImp) Shutdown(context.Context) error {
 	return nil
 }
 
 func (e *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if e.config.AttributeSource == ResourceAttributeSource {
+		return e.routeTracesByResource(ctx, td)
+	}
+
 	value := e.extractValueFromContext(ctx)
 	if len(value) == 0 {
 		// the attribute's value hasn't been found, send data to the default exporter
@@ -145,6 +228,165 @@ func (e *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error
 	return e.pushDataToExporters(ctx, td, e.traceExporters[value])
 }
 
+func (e *processorImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if e.config.AttributeSource == ResourceAttributeSource {
+		return e.routeMetricsByResource(ctx, md)
+	}
+
+	value := e.extractValueFromContext(ctx)
+	exporters, ok := e.metricsExporters[value]
+	if len(value) == 0 || !ok {
+		// either the attribute's value hasn't been found, or there are no exporters for the value
+		exporters = e.defaultMetricsExporters
+	}
+
+	return e.pushMetricsToExporters(ctx, md, exporters)
+}
+
+func (e *processorImp) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if e.config.AttributeSource == ResourceAttributeSource {
+		return e.routeLogsByResource(ctx, ld)
+	}
+
+	value := e.extractValueFromContext(ctx)
+	exporters, ok := e.logsExporters[value]
+	if len(value) == 0 || !ok {
+		// either the attribute's value hasn't been found, or there are no exporters for the value
+		exporters = e.defaultLogsExporters
+	}
+
+	return e.pushLogsToExporters(ctx, ld, exporters)
+}
+
+// routeTracesByResource splits the traces per the routing value found in the attributes of each resource,
+// sending each group to the exporters of its route.
+func (e *processorImp) routeTracesByResource(ctx context.Context, td pdata.Traces) error {
+	// the default group is kept apart, as the routing table might have an entry for the empty value
+	defaultGroup := pdata.NewTraces()
+	groups := map[string]pdata.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+
+		route, found := e.extractValueFromResource(rs.Resource())
+		if _, ok := e.traceExporters[route]; !found || !ok {
+			defaultGroup.ResourceSpans().Append(rs)
+			continue
+		}
+
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewTraces()
+			groups[route] = group
+		}
+		group.ResourceSpans().Append(rs)
+	}
+
+	// every group is pushed, even when the exporters of another route fail
+	var errs []error
+	if defaultGroup.ResourceSpans().Len() > 0 {
+		if err := e.pushDataToExporters(ctx, defaultGroup, e.defaultTraceExporters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for route, group := range groups {
+		if err := e.pushDataToExporters(ctx, group, e.traceExporters[route]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+// routeMetricsByResource splits the metrics per the routing value found in the attributes of each resource,
+// sending each group to the exporters of its route.
+func (e *processorImp) routeMetricsByResource(ctx context.Context, md pdata.Metrics) error {
+	// the default group is kept apart, as the routing table might have an entry for the empty value
+	defaultGroup := pdata.NewMetrics()
+	groups := map[string]pdata.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+
+		route, found := e.extractValueFromResource(rm.Resource())
+		if _, ok := e.metricsExporters[route]; !found || !ok {
+			defaultGroup.ResourceMetrics().Append(rm)
+			continue
+		}
+
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewMetrics()
+			groups[route] = group
+		}
+		group.ResourceMetrics().Append(rm)
+	}
+
+	// every group is pushed, even when the exporters of another route fail
+	var errs []error
+	if defaultGroup.ResourceMetrics().Len() > 0 {
+		if err := e.pushMetricsToExporters(ctx, defaultGroup, e.defaultMetricsExporters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for route, group := range groups {
+		if err := e.pushMetricsToExporters(ctx, group, e.metricsExporters[route]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+// routeLogsByResource splits the logs per the routing value found in the attributes of each resource,
+// sending each group to the exporters of its route.
+func (e *processorImp) routeLogsByResource(ctx context.Context, ld pdata.Logs) error {
+	// the default group is kept apart, as the routing table might have an entry for the empty value
+	defaultGroup := pdata.NewLogs()
+	groups := map[string]pdata.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+
+		route, found := e.extractValueFromResource(rl.Resource())
+		if _, ok := e.logsExporters[route]; !found || !ok {
+			defaultGroup.ResourceLogs().Append(rl)
+			continue
+		}
+
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewLogs()
+			groups[route] = group
+		}
+		group.ResourceLogs().Append(rl)
+	}
+
+	// every group is pushed, even when the exporters of another route fail
+	var errs []error
+	if defaultGroup.ResourceLogs().Len() > 0 {
+		if err := e.pushLogsToExporters(ctx, defaultGroup, e.defaultLogsExporters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for route, group := range groups {
+		if err := e.pushLogsToExporters(ctx, group, e.logsExporters[route]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
 func (e *processorImp) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
@@ -160,6 +402,42 @@ func (e *processorImp) pushDataToExporters(ctx context.Context, td pdata.Traces,
 	return nil
 }
 
+func (e *processorImp) pushMetricsToExporters(ctx context.Context, md pdata.Metrics, exporters []component.MetricsExporter) error {
+	// TODO: determine the proper action when errors happen
+	for _, exp := range exporters {
+		if err := exp.ConsumeMetrics(ctx, md); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *processorImp) pushLogsToExporters(ctx context.Context, ld pdata.Logs, exporters []component.LogsExporter) error {
+	// TODO: determine the proper action when errors happen
+	for _, exp := range exporters {
+		if err := exp.ConsumeLogs(ctx, ld); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractValueFromResource returns the value of the routing attribute of the resource, and whether it has been found
+func (e *processorImp) extractValueFromResource(resource pdata.Resource) (string, bool) {
+	if resource.IsNil() {
+		return "", false
+	}
+
+	value, ok := resource.Attributes().Get(e.config.FromAttribute)
+	if !ok {
+		return "", false
+	}
+
+	return value.StringVal(), true
+}
+
 func (e *processorImp) extractValueFromContext(ctx context.Context) string {
 	// right now, we only support looking up attributes from requests that have gone through the gRPC server
 	// in that case, it will add the HTTP headers as context metadata
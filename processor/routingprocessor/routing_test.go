@@ -110,6 +110,451 @@ func TestDefaultRouteIsUsedWhenRouteCantBeDetermined(t *testing.T) {
 	}
 }
 
+func TestMetricsRouteIsFoundForGRPCContexts(t *testing.T) {
+	// prepare
+	var defaultExp, acmeExp int
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "X-Tenant",
+		},
+		logger: zap.NewNop(),
+		defaultMetricsExporters: []component.MetricsExporter{
+			&mockExporter{
+				ConsumeMetricsFunc: func(context.Context, pdata.Metrics) error {
+					defaultExp++
+					return nil
+				},
+			},
+		},
+		metricsExporters: map[string][]component.MetricsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeMetricsFunc: func(context.Context, pdata.Metrics) error {
+						acmeExp++
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	errAcme := exp.ConsumeMetrics(metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme")), pdata.NewMetrics())
+	errGlobex := exp.ConsumeMetrics(metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "globex")), pdata.NewMetrics())
+	errNoValue := exp.ConsumeMetrics(context.Background(), pdata.NewMetrics())
+
+	// verify
+	assert.NoError(t, errAcme)
+	assert.NoError(t, errGlobex)
+	assert.NoError(t, errNoValue)
+	assert.Equal(t, 1, acmeExp)
+	assert.Equal(t, 2, defaultExp)
+}
+
+func TestLogsRouteIsFoundForGRPCContexts(t *testing.T) {
+	// prepare
+	var defaultExp, acmeExp int
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "X-Tenant",
+		},
+		logger: zap.NewNop(),
+		defaultLogsExporters: []component.LogsExporter{
+			&mockExporter{
+				ConsumeLogsFunc: func(context.Context, pdata.Logs) error {
+					defaultExp++
+					return nil
+				},
+			},
+		},
+		logsExporters: map[string][]component.LogsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeLogsFunc: func(context.Context, pdata.Logs) error {
+						acmeExp++
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	errAcme := exp.ConsumeLogs(metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme")), pdata.NewLogs())
+	errGlobex := exp.ConsumeLogs(metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "globex")), pdata.NewLogs())
+	errNoValue := exp.ConsumeLogs(context.Background(), pdata.NewLogs())
+
+	// verify
+	assert.NoError(t, errAcme)
+	assert.NoError(t, errGlobex)
+	assert.NoError(t, errNoValue)
+	assert.Equal(t, 1, acmeExp)
+	assert.Equal(t, 2, defaultExp)
+}
+
+func TestRegisterExportersForEachDataType(t *testing.T) {
+	otlpConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "otlp",
+			TypeVal: "otlp",
+		},
+	}
+	otlpExp := &mockExporter{}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.TracesDataType: {
+					otlpConfig: otlpExp,
+				},
+				configmodels.MetricsDataType: {
+					otlpConfig: otlpExp,
+				},
+				configmodels.LogsDataType: {
+					otlpConfig: otlpExp,
+				},
+			}
+		},
+	}
+
+	for _, dataType := range []configmodels.DataType{configmodels.TracesDataType, configmodels.MetricsDataType, configmodels.LogsDataType} {
+		t.Run(string(dataType), func(t *testing.T) {
+			//  prepare
+			exp, err := newProcessor(zap.NewNop(), &Config{
+				DefaultExporters: []string{"otlp"},
+				FromAttribute:    "X-Tenant",
+				Table: []RoutingTableItem{
+					{
+						Value:     "acme",
+						Exporters: []string{"otlp"},
+					},
+				},
+			}, dataType)
+			require.NoError(t, err)
+
+			// test
+			err = exp.Start(context.Background(), host)
+
+			// verify
+			require.NoError(t, err)
+			switch dataType {
+			case configmodels.TracesDataType:
+				assert.Len(t, exp.defaultTraceExporters, 1)
+				assert.Len(t, exp.traceExporters["acme"], 1)
+			case configmodels.MetricsDataType:
+				assert.Len(t, exp.defaultMetricsExporters, 1)
+				assert.Len(t, exp.metricsExporters["acme"], 1)
+			case configmodels.LogsDataType:
+				assert.Len(t, exp.defaultLogsExporters, 1)
+				assert.Len(t, exp.logsExporters["acme"], 1)
+			}
+		})
+	}
+}
+
+func TestErrorRequestedExporterDoesNotSupportDataType(t *testing.T) {
+	//  prepare
+	exp, err := newProcessor(zap.NewNop(), &Config{
+		FromAttribute: "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"jaeger"},
+			},
+		},
+	}, configmodels.MetricsDataType)
+	require.NoError(t, err)
+
+	jaegerConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "jaeger",
+			TypeVal: "jaeger",
+		},
+	}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.TracesDataType: {
+					jaegerConfig: &mockExporter{},
+				},
+			}
+		},
+	}
+
+	// test
+	err = exp.Start(context.Background(), host)
+
+	// verify
+	assert.True(t, errors.Is(err, errExporterNotSupported))
+}
+
+func TestTracesAreSplitByResourceAttribute(t *testing.T) {
+	// prepare
+	var defaultTraces, acmeTraces []pdata.Traces
+	exp := &processorImp{
+		config: Config{
+			FromAttribute:   "X-Tenant",
+			AttributeSource: ResourceAttributeSource,
+		},
+		logger: zap.NewNop(),
+		defaultTraceExporters: []component.TracesExporter{
+			&mockExporter{
+				ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+					defaultTraces = append(defaultTraces, td)
+					return nil
+				},
+			},
+		},
+		traceExporters: map[string][]component.TracesExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						acmeTraces = append(acmeTraces, td)
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().Resize(4)
+	for i, tenant := range []string{"acme", "globex", "acme", ""} {
+		rs := traces.ResourceSpans().At(i)
+		rs.Resource().InitEmpty()
+		if len(tenant) > 0 {
+			rs.Resource().Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+
+	// test
+	// the context value is ignored when reading from the resource
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme"))
+	err := exp.ConsumeTraces(ctx, traces)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, acmeTraces, 1)
+	assert.Equal(t, 2, acmeTraces[0].ResourceSpans().Len())
+	require.Len(t, defaultTraces, 1)
+	assert.Equal(t, 2, defaultTraces[0].ResourceSpans().Len())
+}
+
+func TestTracesAreRoutedToAllGroupsWhenAnExporterFails(t *testing.T) {
+	// prepare
+	expectedErr := errors.New("some error")
+	var defaultTraces, acmeTraces, globexTraces []pdata.Traces
+	exp := &processorImp{
+		config: Config{
+			FromAttribute:   "X-Tenant",
+			AttributeSource: ResourceAttributeSource,
+		},
+		logger: zap.NewNop(),
+		defaultTraceExporters: []component.TracesExporter{
+			&mockExporter{
+				ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+					defaultTraces = append(defaultTraces, td)
+					return expectedErr
+				},
+			},
+		},
+		traceExporters: map[string][]component.TracesExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						acmeTraces = append(acmeTraces, td)
+						return expectedErr
+					},
+				},
+			},
+			"globex": {
+				&mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						globexTraces = append(globexTraces, td)
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().Resize(3)
+	for i, tenant := range []string{"acme", "globex", "initech"} {
+		rs := traces.ResourceSpans().At(i)
+		rs.Resource().InitEmpty()
+		rs.Resource().Attributes().InsertString("X-Tenant", tenant)
+	}
+
+	// test
+	err := exp.ConsumeTraces(context.Background(), traces)
+
+	// verify
+	assert.Error(t, err)
+	assert.Len(t, defaultTraces, 1)
+	assert.Len(t, acmeTraces, 1)
+	assert.Len(t, globexTraces, 1)
+}
+
+func TestTracesWithoutAttributeDontUseTheRouteForEmptyValues(t *testing.T) {
+	// prepare
+	var defaultTraces, emptyTraces []pdata.Traces
+	exp := &processorImp{
+		config: Config{
+			FromAttribute:   "X-Tenant",
+			AttributeSource: ResourceAttributeSource,
+		},
+		logger: zap.NewNop(),
+		defaultTraceExporters: []component.TracesExporter{
+			&mockExporter{
+				ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+					defaultTraces = append(defaultTraces, td)
+					return nil
+				},
+			},
+		},
+		traceExporters: map[string][]component.TracesExporter{
+			"": {
+				&mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						emptyTraces = append(emptyTraces, td)
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	traces := pdata.NewTraces()
+	traces.ResourceSpans().Resize(3)
+	for i := 0; i < 3; i++ {
+		traces.ResourceSpans().At(i).Resource().InitEmpty()
+	}
+	// only the first resource has the attribute, with an empty value
+	traces.ResourceSpans().At(0).Resource().Attributes().InsertString("X-Tenant", "")
+
+	// test
+	err := exp.ConsumeTraces(context.Background(), traces)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, emptyTraces, 1)
+	assert.Equal(t, 1, emptyTraces[0].ResourceSpans().Len())
+	require.Len(t, defaultTraces, 1)
+	assert.Equal(t, 2, defaultTraces[0].ResourceSpans().Len())
+}
+
+func TestMetricsAreSplitByResourceAttribute(t *testing.T) {
+	// prepare
+	var defaultMetrics, acmeMetrics []pdata.Metrics
+	exp := &processorImp{
+		config: Config{
+			FromAttribute:   "X-Tenant",
+			AttributeSource: ResourceAttributeSource,
+		},
+		logger: zap.NewNop(),
+		defaultMetricsExporters: []component.MetricsExporter{
+			&mockExporter{
+				ConsumeMetricsFunc: func(_ context.Context, md pdata.Metrics) error {
+					defaultMetrics = append(defaultMetrics, md)
+					return nil
+				},
+			},
+		},
+		metricsExporters: map[string][]component.MetricsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeMetricsFunc: func(_ context.Context, md pdata.Metrics) error {
+						acmeMetrics = append(acmeMetrics, md)
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	metrics := pdata.NewMetrics()
+	metrics.ResourceMetrics().Resize(3)
+	for i, tenant := range []string{"acme", "globex", "acme"} {
+		rm := metrics.ResourceMetrics().At(i)
+		rm.Resource().InitEmpty()
+		rm.Resource().Attributes().InsertString("X-Tenant", tenant)
+	}
+
+	// test
+	err := exp.ConsumeMetrics(context.Background(), metrics)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, acmeMetrics, 1)
+	assert.Equal(t, 2, acmeMetrics[0].ResourceMetrics().Len())
+	require.Len(t, defaultMetrics, 1)
+	assert.Equal(t, 1, defaultMetrics[0].ResourceMetrics().Len())
+}
+
+func TestLogsAreSplitByResourceAttribute(t *testing.T) {
+	// prepare
+	var defaultLogs, acmeLogs []pdata.Logs
+	exp := &processorImp{
+		config: Config{
+			FromAttribute:   "X-Tenant",
+			AttributeSource: ResourceAttributeSource,
+		},
+		logger: zap.NewNop(),
+		defaultLogsExporters: []component.LogsExporter{
+			&mockExporter{
+				ConsumeLogsFunc: func(_ context.Context, ld pdata.Logs) error {
+					defaultLogs = append(defaultLogs, ld)
+					return nil
+				},
+			},
+		},
+		logsExporters: map[string][]component.LogsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeLogsFunc: func(_ context.Context, ld pdata.Logs) error {
+						acmeLogs = append(acmeLogs, ld)
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	logs := pdata.NewLogs()
+	logs.ResourceLogs().Resize(2)
+	for i, tenant := range []string{"acme", "acme"} {
+		rl := logs.ResourceLogs().At(i)
+		rl.Resource().InitEmpty()
+		rl.Resource().Attributes().InsertString("X-Tenant", tenant)
+	}
+
+	// test
+	err := exp.ConsumeLogs(context.Background(), logs)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, acmeLogs, 1)
+	assert.Equal(t, 2, acmeLogs[0].ResourceLogs().Len())
+	assert.Len(t, defaultLogs, 0)
+}
+
+func TestInvalidAttributeSource(t *testing.T) {
+	// test
+	_, err := newProcessor(zap.NewNop(), &Config{
+		FromAttribute:   "X-Tenant",
+		AttributeSource: "header",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	}, configmodels.TracesDataType)
+
+	// verify
+	assert.True(t, errors.Is(err, errInvalidAttributeSource))
+}
+
 func TestRegisterExportersForValidRoute(t *testing.T) {
 	//  prepare
 	exp, err := newProcessor(zap.NewNop(), &Config{
@@ -121,7 +566,7 @@ func TestRegisterExportersForValidRoute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpExpFactory := otlpexporter.NewFactory()
@@ -164,7 +609,7 @@ func TestErrorRequestedExporterNotFoundForRoute(t *testing.T) {
 				Exporters: []string{"non-existing"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	host := &mockHost{}
 
@@ -186,7 +631,7 @@ func TestErrorRequestedExporterNotFoundForDefaultRoute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpExpFactory := otlpexporter.NewFactory()
@@ -230,7 +675,7 @@ func TestInvalidExporter(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpConfig := &otlpexporter.Config{
@@ -267,7 +712,7 @@ func TestValueFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme"))
 
@@ -289,7 +734,7 @@ func TestMultipleValuesFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "globex", "X-Tenant", "acme"))
 
@@ -311,7 +756,7 @@ func TestNoValuesFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", ""))
 
@@ -333,7 +778,7 @@ func TestAttributeFromExistingGRPCContext(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{}))
 
@@ -355,7 +800,7 @@ func TestNoAttributeInContext(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	// test
@@ -409,7 +854,7 @@ func TestProcessorCapabilities(t *testing.T) {
 	}
 
 	// test
-	p, err := newProcessor(zap.NewNop(), config)
+	p, err := newProcessor(zap.NewNop(), config, configmodels.TracesDataType)
 	caps := p.GetCapabilities()
 
 	// verify
@@ -441,7 +886,9 @@ func (m *mockComponent) Shutdown(context.Context) error {
 
 type mockExporter struct {
 	mockComponent
-	ConsumeTracesFunc func(ctx context.Context, td pdata.Traces) error
+	ConsumeTracesFunc  func(ctx context.Context, td pdata.Traces) error
+	ConsumeMetricsFunc func(ctx context.Context, md pdata.Metrics) error
+	ConsumeLogsFunc    func(ctx context.Context, ld pdata.Logs) error
 }
 
 func (m *mockExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
@@ -450,3 +897,17 @@ func (m *mockExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error
 	}
 	return nil
 }
+
+func (m *mockExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if m.ConsumeMetricsFunc != nil {
+		return m.ConsumeMetricsFunc(ctx, md)
+	}
+	return nil
+}
+
+func (m *mockExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if m.ConsumeLogsFunc != nil {
+		return m.ConsumeLogsFunc(ctx, ld)
+	}
+	return nil
+}
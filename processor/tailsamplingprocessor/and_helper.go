@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/sampling"
+)
+
+func getNewAndPolicy(logger *zap.Logger, config AndCfg) (sampling.PolicyEvaluator, error) {
+	var subpolicyEvaluators []sampling.PolicyEvaluator
+	for i := range config.SubPolicyCfg {
+		policyCfg := &config.SubPolicyCfg[i]
+		if policyCfg.Type == And || policyCfg.Type == Composite {
+			return nil, fmt.Errorf("policy type %s can't be used as a sub-policy of the and policy", policyCfg.Type)
+		}
+
+		policy, err := getSharedPolicyEvaluator(logger, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		subpolicyEvaluators = append(subpolicyEvaluators, policy)
+	}
+	return sampling.NewAnd(logger, subpolicyEvaluators), nil
+}
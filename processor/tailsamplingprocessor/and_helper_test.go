@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/sampling"
+)
+
+func TestAndHelper(t *testing.T) {
+	cfg := &PolicyCfg{
+		Name: "and-policy",
+		Type: And,
+		AndCfg: AndCfg{
+			SubPolicyCfg: []PolicyCfg{
+				{
+					Name:                "test-and-policy-1",
+					Type:                NumericAttribute,
+					NumericAttributeCfg: NumericAttributeCfg{Key: "http.status_code", MinValue: 500, MaxValue: 599},
+				},
+				{
+					Name:               "test-and-policy-2",
+					Type:               StringAttribute,
+					StringAttributeCfg: StringAttributeCfg{Key: "service.name", Values: []string{"checkout"}},
+				},
+			},
+		},
+	}
+
+	actual, err := getPolicyEvaluator(zap.NewNop(), cfg)
+	require.NoError(t, err)
+
+	expected := sampling.NewAnd(zap.NewNop(), []sampling.PolicyEvaluator{
+		sampling.NewNumericAttributeFilter(zap.NewNop(), "http.status_code", 500, 599),
+		sampling.NewStringAttributeFilter(zap.NewNop(), "service.name", []string{"checkout"}),
+	})
+	assert.Equal(t, expected, actual)
+}
+
+func TestAndHelperWithNestedPolicies(t *testing.T) {
+	for _, typ := range []PolicyType{And, Composite} {
+		t.Run(string(typ), func(t *testing.T) {
+			cfg := AndCfg{
+				SubPolicyCfg: []PolicyCfg{
+					{
+						Name: "nested",
+						Type: typ,
+					},
+				},
+			}
+
+			_, err := getNewAndPolicy(zap.NewNop(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAndHelperWithUnknownSubPolicy(t *testing.T) {
+	cfg := AndCfg{
+		SubPolicyCfg: []PolicyCfg{
+			{
+				Name: "unknown",
+				Type: PolicyType("unknown"),
+			},
+		},
+	}
+
+	_, err := getNewAndPolicy(zap.NewNop(), cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/sampling"
+)
+
+func getNewCompositePolicy(logger *zap.Logger, config CompositeCfg) (sampling.PolicyEvaluator, error) {
+	subPolicyCfgs := make(map[string]*PolicyCfg)
+	for i := range config.SubPolicyCfg {
+		policyCfg := &config.SubPolicyCfg[i]
+		if policyCfg.Type == Composite {
+			return nil, fmt.Errorf("policy type %s can't be used as a sub-policy of the composite policy", policyCfg.Type)
+		}
+		if _, exists := subPolicyCfgs[policyCfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate sub-policy name %q in the composite policy", policyCfg.Name)
+		}
+		subPolicyCfgs[policyCfg.Name] = policyCfg
+	}
+
+	rateAllocations, err := getRateAllocationMap(config, subPolicyCfgs)
+	if err != nil {
+		return nil, err
+	}
+
+	policyOrder := config.PolicyOrder
+	if len(policyOrder) == 0 {
+		for _, policyCfg := range config.SubPolicyCfg {
+			policyOrder = append(policyOrder, policyCfg.Name)
+		}
+	}
+
+	var subPolicyEvalParams []sampling.SubPolicyEvalParams
+	for _, name := range policyOrder {
+		policyCfg, ok := subPolicyCfgs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sub-policy %q in the policy order of the composite policy", name)
+		}
+
+		policy, err := getCompositeSubPolicyEvaluator(logger, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		subPolicyEvalParams = append(subPolicyEvalParams, sampling.SubPolicyEvalParams{
+			Evaluator:         policy,
+			MaxSpansPerSecond: rateAllocations[name],
+		})
+	}
+
+	return sampling.NewComposite(logger, config.MaxTotalSpansPerSecond, subPolicyEvalParams), nil
+}
+
+// getRateAllocationMap returns the number of spans per second allocated to each of the sub-policies
+func getRateAllocationMap(config CompositeCfg, subPolicyCfgs map[string]*PolicyCfg) (map[string]int64, error) {
+	rateAllocations := make(map[string]int64)
+	var totalPercent int64
+	for _, allocation := range config.RateAllocation {
+		if _, ok := subPolicyCfgs[allocation.Policy]; !ok {
+			return nil, fmt.Errorf("unknown sub-policy %q in the rate allocation of the composite policy", allocation.Policy)
+		}
+		if allocation.Percent < 0 {
+			return nil, fmt.Errorf("invalid rate allocation of %d%% for the sub-policy %q", allocation.Percent, allocation.Policy)
+		}
+
+		totalPercent += allocation.Percent
+		rateAllocations[allocation.Policy] = config.MaxTotalSpansPerSecond * allocation.Percent / 100
+	}
+
+	if totalPercent > 100 {
+		return nil, fmt.Errorf("the rate allocations of the composite policy add up to %d%%, which is more than 100%%", totalPercent)
+	}
+
+	return rateAllocations, nil
+}
+
+func getCompositeSubPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+	if cfg.Type == And {
+		return getNewAndPolicy(logger, cfg.AndCfg)
+	}
+	return getSharedPolicyEvaluator(logger, cfg)
+}
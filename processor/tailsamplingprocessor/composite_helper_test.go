@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func compositeCfg() CompositeCfg {
+	return CompositeCfg{
+		MaxTotalSpansPerSecond: 1000,
+		PolicyOrder:            []string{"test-composite-policy-2", "test-composite-policy-1"},
+		SubPolicyCfg: []PolicyCfg{
+			{
+				Name:                "test-composite-policy-1",
+				Type:                NumericAttribute,
+				NumericAttributeCfg: NumericAttributeCfg{Key: "key1", MinValue: 50, MaxValue: 100},
+			},
+			{
+				Name: "test-composite-policy-2",
+				Type: And,
+				AndCfg: AndCfg{
+					SubPolicyCfg: []PolicyCfg{
+						{
+							Name: "test-and-policy-1",
+							Type: AlwaysSample,
+						},
+					},
+				},
+			},
+		},
+		RateAllocation: []RateAllocationCfg{
+			{
+				Policy:  "test-composite-policy-1",
+				Percent: 25,
+			},
+			{
+				Policy:  "test-composite-policy-2",
+				Percent: 75,
+			},
+		},
+	}
+}
+
+func TestCompositeHelper(t *testing.T) {
+	actual, err := getNewCompositePolicy(zap.NewNop(), compositeCfg())
+	require.NoError(t, err)
+	assert.NotNil(t, actual)
+}
+
+func TestCompositeHelperRateAllocations(t *testing.T) {
+	cfg := compositeCfg()
+	subPolicyCfgs := map[string]*PolicyCfg{
+		"test-composite-policy-1": &cfg.SubPolicyCfg[0],
+		"test-composite-policy-2": &cfg.SubPolicyCfg[1],
+	}
+
+	allocations, err := getRateAllocationMap(cfg, subPolicyCfgs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"test-composite-policy-1": 250,
+		"test-composite-policy-2": 750,
+	}, allocations)
+}
+
+func TestCompositeHelperInvalidConfigs(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		modify func(cfg *CompositeCfg)
+	}{
+		{
+			"nested composite policy",
+			func(cfg *CompositeCfg) {
+				cfg.SubPolicyCfg[0].Type = Composite
+			},
+		},
+		{
+			"duplicate sub-policy name",
+			func(cfg *CompositeCfg) {
+				cfg.SubPolicyCfg[1].Name = cfg.SubPolicyCfg[0].Name
+			},
+		},
+		{
+			"unknown policy in the policy order",
+			func(cfg *CompositeCfg) {
+				cfg.PolicyOrder = append(cfg.PolicyOrder, "unknown")
+			},
+		},
+		{
+			"unknown policy in the rate allocation",
+			func(cfg *CompositeCfg) {
+				cfg.RateAllocation[0].Policy = "unknown"
+			},
+		},
+		{
+			"rate allocations above 100%",
+			func(cfg *CompositeCfg) {
+				cfg.RateAllocation[0].Percent = 50
+			},
+		},
+		{
+			"negative rate allocation",
+			func(cfg *CompositeCfg) {
+				cfg.RateAllocation[0].Percent = -1
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := compositeCfg()
+			tt.modify(&cfg)
+
+			_, err := getNewCompositePolicy(zap.NewNop(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompositeHelperDefaultPolicyOrder(t *testing.T) {
+	cfg := compositeCfg()
+	cfg.PolicyOrder = nil
+
+	actual, err := getNewCompositePolicy(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, actual)
+}
@@ -34,6 +34,19 @@ const (
 	StringAttribute PolicyType = "string_attribute"
 	// RateLimiting allows all traces until the specified limits are satisfied.
 	RateLimiting PolicyType = "rate_limiting"
+	// Latency samples traces lasting longer than a given threshold, from the earliest span
+	// start to the latest span end.
+	Latency PolicyType = "latency"
+	// StatusCode samples traces that have at least one span with an error status.
+	StatusCode PolicyType = "status_code"
+	// Probabilistic samples a given percentage of traces, based on a hash of the trace ID.
+	Probabilistic PolicyType = "probabilistic"
+	// And samples traces that are sampled by all of its sub-policies, e.g.: service "checkout" and
+	// attribute "http.status_code" >= 500.
+	And PolicyType = "and"
+	// Composite evaluates its sub-policies in order, sampling traces up to the share of the
+	// spans per second allocated to the sub-policy that sampled them.
+	Composite PolicyType = "composite"
 )
 
 // PolicyCfg holds the common configuration to all policies.
@@ -48,6 +61,14 @@ type PolicyCfg struct {
 	StringAttributeCfg StringAttributeCfg `mapstructure:"string_attribute"`
 	// Configs for rate limiting filter sampling policy evaluator.
 	RateLimitingCfg RateLimitingCfg `mapstructure:"rate_limiting"`
+	// Configs for latency filter sampling policy evaluator.
+	LatencyCfg LatencyCfg `mapstructure:"latency"`
+	// Configs for probabilistic sampling policy evaluator.
+	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
+	// Configs for the and policy evaluator, combining other policies.
+	AndCfg AndCfg `mapstructure:"and"`
+	// Configs for the composite policy evaluator, combining other policies.
+	CompositeCfg CompositeCfg `mapstructure:"composite"`
 }
 
 // NumericAttributeCfg holds the configurable settings to create a numeric attribute filter
@@ -68,6 +89,14 @@ type StringAttributeCfg struct {
 	Key string `mapstructure:"key"`
 	// Values is the set of attribute values that if any is equal to the actual attribute value to be considered a match.
 	Values []string `mapstructure:"values"`
+	// EnabledRegexMatching determines whether the values are matched as regular expressions.
+	EnabledRegexMatching bool `mapstructure:"enabled_regex_matching"`
+	// CacheMaxSize is the maximum number of attribute values whose regex match result is cached.
+	// Only used when EnabledRegexMatching is set, defaults to 128.
+	CacheMaxSize int `mapstructure:"cache_max_size"`
+	// InvertMatch samples the traces that have no attribute matching the values instead,
+	// e.g.: all traces except the health checks.
+	InvertMatch bool `mapstructure:"invert_match"`
 }
 
 // RateLimitingCfg holds the configurable settings to create a rate limiting
@@ -77,6 +106,69 @@ type RateLimitingCfg struct {
 	SpansPerSecond int64 `mapstructure:"spans_per_second"`
 }
 
+// LatencyCfg holds the configurable settings to create a latency filter sampling policy
+// evaluator.
+type LatencyCfg struct {
+	// ThresholdMs is the minimum duration, in milliseconds, of the traces to be sampled.
+	ThresholdMs int64 `mapstructure:"threshold_ms"`
+}
+
+// ProbabilisticCfg holds the configurable settings to create a probabilistic
+// sampling policy evaluator.
+type ProbabilisticCfg struct {
+	// HashSalt allows one to configure the hashing salts. This is important in scenarios where multiple layers of collectors
+	// have different sampling rates: if they use the same salt all passing one layer may pass the other even if they have
+	// different sampling rates, configuring different salts avoids that.
+	HashSalt string `mapstructure:"hash_salt"`
+	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
+	// Values greater or equal 100 are treated as "sample all traces".
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+}
+
+// AndCfg holds the configurable settings to create an and sampling policy evaluator.
+type AndCfg struct {
+	// SubPolicyCfg is the list of policies that must all sample a trace for it to be sampled.
+	// The and and composite policies can't be used as sub-policies.
+	SubPolicyCfg []PolicyCfg `mapstructure:"and_sub_policy"`
+}
+
+// CompositeCfg holds the configurable settings to create a composite sampling policy evaluator.
+type CompositeCfg struct {
+	// MaxTotalSpansPerSecond is the maximum number of spans sampled each second by all the sub-policies together.
+	MaxTotalSpansPerSecond int64 `mapstructure:"max_total_spans_per_second"`
+	// PolicyOrder is the order in which the sub-policies are evaluated, by name. The first sub-policy
+	// sampling a trace makes the decision. When not set, the sub-policies are evaluated in the order
+	// they are defined.
+	PolicyOrder []string `mapstructure:"policy_order"`
+	// SubPolicyCfg is the list of policies combined by this policy. The composite policy can't be used
+	// as a sub-policy.
+	SubPolicyCfg []PolicyCfg `mapstructure:"composite_sub_policy"`
+	// RateAllocation is the share of MaxTotalSpansPerSecond allocated to each sub-policy. Sub-policies
+	// exceeding their share, or without a share, may still sample traces using the capacity left unused
+	// by the other sub-policies.
+	RateAllocation []RateAllocationCfg `mapstructure:"rate_allocation"`
+}
+
+// RateAllocationCfg holds the share of the spans per second allocated to a sub-policy of the composite policy.
+type RateAllocationCfg struct {
+	// Policy is the name of the sub-policy.
+	Policy string `mapstructure:"policy"`
+	// Percent is the percentage of the composite policy's MaxTotalSpansPerSecond allocated to the sub-policy.
+	Percent int64 `mapstructure:"percent"`
+}
+
+// DecisionCacheCfg holds the configurable settings of the cache keeping the sampling decisions
+// of the traces, so that spans arriving after the trace was removed from memory follow the
+// original decision.
+type DecisionCacheCfg struct {
+	// NumTraces is the maximum number of decisions kept in the cache. The cache is disabled
+	// when zero.
+	NumTraces int `mapstructure:"num_traces"`
+	// TTL is the time a decision is kept in the cache after being made. Decisions are kept
+	// until evicted from the cache when zero.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
@@ -92,4 +184,7 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache holds the settings of the cache keeping the sampling decisions after the traces
+	// are removed from memory.
+	DecisionCache DecisionCacheCfg `mapstructure:"decision_cache"`
 }
@@ -46,6 +46,10 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache: DecisionCacheCfg{
+				NumTraces: 1000,
+				TTL:       5 * time.Minute,
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -66,6 +70,83 @@ func TestLoadConfig(t *testing.T) {
 					Type:            RateLimiting,
 					RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
 				},
+				{
+					Name:       "test-policy-5",
+					Type:       Latency,
+					LatencyCfg: LatencyCfg{ThresholdMs: 5000},
+				},
+				{
+					Name: "test-policy-6",
+					Type: StatusCode,
+				},
+				{
+					Name:             "test-policy-7",
+					Type:             Probabilistic,
+					ProbabilisticCfg: ProbabilisticCfg{HashSalt: "custom-salt", SamplingPercentage: 10},
+				},
+				{
+					Name: "test-policy-8",
+					Type: And,
+					AndCfg: AndCfg{
+						SubPolicyCfg: []PolicyCfg{
+							{
+								Name:                "test-and-policy-1",
+								Type:                NumericAttribute,
+								NumericAttributeCfg: NumericAttributeCfg{Key: "http.status_code", MinValue: 500, MaxValue: 599},
+							},
+							{
+								Name:               "test-and-policy-2",
+								Type:               StringAttribute,
+								StringAttributeCfg: StringAttributeCfg{Key: "service.name", Values: []string{"checkout"}},
+							},
+						},
+					},
+				},
+				{
+					Name: "test-policy-9",
+					Type: Composite,
+					CompositeCfg: CompositeCfg{
+						MaxTotalSpansPerSecond: 1000,
+						PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3"},
+						SubPolicyCfg: []PolicyCfg{
+							{
+								Name:                "test-composite-policy-1",
+								Type:                NumericAttribute,
+								NumericAttributeCfg: NumericAttributeCfg{Key: "key1", MinValue: 50, MaxValue: 100},
+							},
+							{
+								Name:               "test-composite-policy-2",
+								Type:               StringAttribute,
+								StringAttributeCfg: StringAttributeCfg{Key: "key2", Values: []string{"value1", "value2"}},
+							},
+							{
+								Name: "test-composite-policy-3",
+								Type: AlwaysSample,
+							},
+						},
+						RateAllocation: []RateAllocationCfg{
+							{
+								Policy:  "test-composite-policy-1",
+								Percent: 50,
+							},
+							{
+								Policy:  "test-composite-policy-2",
+								Percent: 25,
+							},
+						},
+					},
+				},
+				{
+					Name: "test-policy-10",
+					Type: StringAttribute,
+					StringAttributeCfg: StringAttributeCfg{
+						Key:                  "http.target",
+						Values:               []string{"/health.*", "/metrics"},
+						EnabledRegexMatching: true,
+						CacheMaxSize:         10,
+						InvertMatch:          true,
+					},
+				},
 			},
 		})
 }
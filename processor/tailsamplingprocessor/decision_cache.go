@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/sampling"
+)
+
+// cachedDecision is the outcome of the evaluation of the policies for a trace.
+type cachedDecision struct {
+	// decisions made by each of the policies, in the same order as the processor policies.
+	decisions []sampling.Decision
+	// decisionTime is the time the decision was made.
+	decisionTime time.Time
+}
+
+// decisionCache keeps the sampling decisions of the traces, so that spans arriving after the
+// trace was removed from memory follow the original decision. The cache is bounded in size,
+// evicting the least recently used decisions first, and each decision expires after the TTL.
+type decisionCache struct {
+	cache *lru.Cache
+	ttl   time.Duration
+	// now is used to allow tests to control the time.
+	now func() time.Time
+}
+
+// newDecisionCache creates a decision cache keeping up to size decisions. A zero TTL keeps the
+// decisions until they are evicted.
+func newDecisionCache(size int, ttl time.Duration) (*decisionCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &decisionCache{
+		cache: cache,
+		ttl:   ttl,
+		now:   time.Now,
+	}, nil
+}
+
+// set records the decisions made for the given trace.
+func (c *decisionCache) set(id traceKey, decisions []sampling.Decision, decisionTime time.Time) {
+	// the trace data decisions are still updated by the processor, so a copy is kept
+	d := make([]sampling.Decision, len(decisions))
+	copy(d, decisions)
+	c.cache.Add(id, &cachedDecision{
+		decisions:    d,
+		decisionTime: decisionTime,
+	})
+}
+
+// get returns the decisions made for the given trace, if they are still in the cache.
+func (c *decisionCache) get(id traceKey) (*cachedDecision, bool) {
+	v, ok := c.cache.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	cached := v.(*cachedDecision)
+	if c.ttl > 0 && c.now().Sub(cached.decisionTime) > c.ttl {
+		c.cache.Remove(id)
+		return nil, false
+	}
+
+	return cached, true
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/sampling"
+)
+
+func TestDecisionCacheSetAndGet(t *testing.T) {
+	// prepare
+	c, err := newDecisionCache(10, time.Minute)
+	require.NoError(t, err)
+
+	id := traceKey{1, 2, 3, 4}
+	decisions := []sampling.Decision{sampling.NotSampled, sampling.Sampled}
+
+	// test
+	c.set(id, decisions, time.Now())
+	decisions[0] = sampling.Sampled // changes after the decision was cached aren't seen
+	cached, found := c.get(id)
+
+	// verify
+	require.True(t, found)
+	assert.Equal(t, []sampling.Decision{sampling.NotSampled, sampling.Sampled}, cached.decisions)
+
+	_, found = c.get(traceKey{4, 3, 2, 1})
+	assert.False(t, found)
+}
+
+func TestDecisionCacheExpiresDecisions(t *testing.T) {
+	// prepare
+	c, err := newDecisionCache(10, time.Minute)
+	require.NoError(t, err)
+
+	now := time.Now()
+	c.now = func() time.Time {
+		return now
+	}
+
+	id := traceKey{1, 2, 3, 4}
+	c.set(id, []sampling.Decision{sampling.Sampled}, now)
+
+	// test
+	_, foundBeforeTTL := c.get(id)
+	now = now.Add(2 * time.Minute)
+	_, foundAfterTTL := c.get(id)
+
+	// verify
+	assert.True(t, foundBeforeTTL)
+	assert.False(t, foundAfterTTL)
+	assert.Equal(t, 0, c.cache.Len())
+}
+
+func TestDecisionCacheWithoutTTL(t *testing.T) {
+	// prepare
+	c, err := newDecisionCache(10, 0)
+	require.NoError(t, err)
+
+	id := traceKey{1, 2, 3, 4}
+	c.set(id, []sampling.Decision{sampling.Sampled}, time.Now().Add(-24*time.Hour))
+
+	// test
+	_, found := c.get(id)
+
+	// verify
+	assert.True(t, found)
+}
+
+func TestDecisionCacheIsBounded(t *testing.T) {
+	// prepare
+	c, err := newDecisionCache(2, time.Minute)
+	require.NoError(t, err)
+
+	// test
+	c.set(traceKey{1}, []sampling.Decision{sampling.Sampled}, time.Now())
+	c.set(traceKey{2}, []sampling.Decision{sampling.Sampled}, time.Now())
+	c.set(traceKey{3}, []sampling.Decision{sampling.Sampled}, time.Now())
+
+	// verify
+	_, found := c.get(traceKey{1})
+	assert.False(t, found, "the oldest decision should have been evicted")
+	_, found = c.get(traceKey{3})
+	assert.True(t, found)
+}
+
+func TestDecisionCacheInvalidSize(t *testing.T) {
+	_, err := newDecisionCache(0, time.Minute)
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/google/uuid v1.1.2
+	github.com/hashicorp/golang-lru v0.5.4
 	github.com/stretchr/testify v1.6.1
 	go.opencensus.io v0.22.5
 	go.opentelemetry.io/collector v0.13.1-0.20201101004512-f4e4382d0e0e
@@ -41,6 +41,9 @@ var (
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount  = stats.Int64("sampling_decision_cache_hit", "Count of late spans whose trace decision was found in the decision cache", stats.UnitDimensionless)
+	statDecisionCacheMissCount = stats.Int64("sampling_decision_cache_miss", "Count of new traces whose decision wasn't found in the decision cache", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -116,6 +119,19 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.LastValue(),
 	}
 
+	countDecisionCacheHitView := &view.View{
+		Name:        statDecisionCacheHitCount.Name(),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		Aggregation: view.Sum(),
+	}
+	countDecisionCacheMissView := &view.View{
+		Name:        statDecisionCacheMissCount.Name(),
+		Measure:     statDecisionCacheMissCount,
+		Description: statDecisionCacheMissCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
 	legacyViews := []*view.View{
 		decisionLatencyView,
 		overallDecisionLatencyView,
@@ -130,6 +146,9 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+
+		countDecisionCacheHitView,
+		countDecisionCacheMissView,
 	}
 
 	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
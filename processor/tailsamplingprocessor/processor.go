@@ -63,6 +63,8 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan traceKey
 	numTracesOnMap  uint64
+	// decisionCache is nil when the decision cache is disabled.
+	decisionCache *decisionCache
 }
 
 const (
@@ -114,10 +116,30 @@ func newTraceProcessor(logger *zap.Logger, nextConsumer consumer.TracesConsumer,
 	tsp.policyTicker = &policyTicker{onTick: tsp.samplingPolicyOnTick}
 	tsp.deleteChan = make(chan traceKey, cfg.NumTraces)
 
+	if cfg.DecisionCache.NumTraces > 0 {
+		tsp.decisionCache, err = newDecisionCache(cfg.DecisionCache.NumTraces, cfg.DecisionCache.TTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return tsp, nil
 }
 
 func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+	switch cfg.Type {
+	case And:
+		return getNewAndPolicy(logger, cfg.AndCfg)
+	case Composite:
+		return getNewCompositePolicy(logger, cfg.CompositeCfg)
+	default:
+		return getSharedPolicyEvaluator(logger, cfg)
+	}
+}
+
+// getSharedPolicyEvaluator builds the evaluators for the policies that can be used both on their own
+// and as sub-policies of the and and composite policies.
+func getSharedPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case AlwaysSample:
 		return sampling.NewAlwaysSample(logger), nil
@@ -126,10 +148,22 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
-		return sampling.NewStringAttributeFilter(logger, safCfg.Key, safCfg.Values), nil
+		return sampling.NewStringAttributeFilterWithOptions(logger, safCfg.Key, safCfg.Values, sampling.StringAttributeFilterOptions{
+			EnabledRegexMatching: safCfg.EnabledRegexMatching,
+			CacheMaxSize:         safCfg.CacheMaxSize,
+			InvertMatch:          safCfg.InvertMatch,
+		})
 	case RateLimiting:
 		rlfCfg := cfg.RateLimitingCfg
 		return sampling.NewRateLimiting(logger, rlfCfg.SpansPerSecond), nil
+	case Latency:
+		lfCfg := cfg.LatencyCfg
+		return sampling.NewLatency(logger, time.Duration(lfCfg.ThresholdMs)*time.Millisecond), nil
+	case StatusCode:
+		return sampling.NewStatusCodeFilter(logger), nil
+	case Probabilistic:
+		pCfg := cfg.ProbabilisticCfg
+		return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -157,6 +191,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
 
+		if tsp.decisionCache != nil {
+			trace.Lock()
+			tsp.decisionCache.set(traceKey(id.Bytes()), trace.Decisions, trace.DecisionTime)
+			trace.Unlock()
+		}
+
 		// Sampled or not, remove the batches
 		trace.Lock()
 		traceBatches := trace.ReceivedBatches
@@ -283,6 +323,18 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.decisionCache != nil {
+			if _, ok := tsp.idToTrace.Load(id); !ok {
+				// the trace might have been removed from memory after its decision was made
+				if cached, ok := tsp.decisionCache.get(id); ok {
+					stats.Record(tsp.ctx, statDecisionCacheHitCount.M(int64(1)))
+					tsp.processLateSpans(cached.decisions, cached.decisionTime, resourceSpans, spans)
+					continue
+				}
+				stats.Record(tsp.ctx, statDecisionCacheMissCount.M(int64(1)))
+			}
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -332,25 +384,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 			}
 			actualData.Unlock()
 
-			switch actualDecision {
-			case sampling.Sampled:
-				// Forward the spans to the policy destinations
-				traceTd := prepareTraceBatch(resourceSpans, spans)
-				if err := tsp.nextConsumer.ConsumeTraces(policy.ctx, traceTd); err != nil {
-					tsp.logger.Warn("Error sending late arrived spans to destination",
-						zap.String("policy", policy.Name),
-						zap.Error(err))
-				}
-				fallthrough // so OnLateArrivingSpans is also called for decision Sampled.
-			case sampling.NotSampled:
-				policy.Evaluator.OnLateArrivingSpans(actualDecision, spans)
-				stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(actualData.DecisionTime)/time.Second)))
-
-			default:
-				tsp.logger.Warn("Encountered unexpected sampling decision",
-					zap.String("policy", policy.Name),
-					zap.Int("decision", int(actualDecision)))
-			}
+			tsp.processLateSpansForPolicy(policy, actualDecision, actualData.DecisionTime, resourceSpans, spans)
 
 			// At this point the late arrival has been passed to nextConsumer. Need to break out of the policy loop
 			// so that it isn't sent to nextConsumer more than once when multiple policies chose to sample
@@ -363,6 +397,46 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// processLateSpans handles the spans arriving after the decision for their trace was made and the trace
+// was removed from memory, according to the decisions kept in the decision cache.
+func (tsp *tailSamplingSpanProcessor) processLateSpans(decisions []sampling.Decision, decisionTime time.Time, resourceSpans pdata.ResourceSpans, spans []*pdata.Span) {
+	for i, policy := range tsp.policies {
+		if i >= len(decisions) {
+			break
+		}
+		tsp.processLateSpansForPolicy(policy, decisions[i], decisionTime, resourceSpans, spans)
+
+		// the late spans are only passed once to the nextConsumer
+		if decisions[i] == sampling.Sampled {
+			break
+		}
+	}
+}
+
+// processLateSpansForPolicy forwards the late spans to the nextConsumer when the policy sampled their trace,
+// and notifies the policy about them.
+func (tsp *tailSamplingSpanProcessor) processLateSpansForPolicy(policy *Policy, decision sampling.Decision, decisionTime time.Time, resourceSpans pdata.ResourceSpans, spans []*pdata.Span) {
+	switch decision {
+	case sampling.Sampled:
+		// Forward the spans to the policy destinations
+		traceTd := prepareTraceBatch(resourceSpans, spans)
+		if err := tsp.nextConsumer.ConsumeTraces(policy.ctx, traceTd); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans to destination",
+				zap.String("policy", policy.Name),
+				zap.Error(err))
+		}
+		fallthrough // so OnLateArrivingSpans is also called for decision Sampled.
+	case sampling.NotSampled:
+		policy.Evaluator.OnLateArrivingSpans(decision, spans)
+		stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(decisionTime)/time.Second)))
+
+	default:
+		tsp.logger.Warn("Encountered unexpected sampling decision",
+			zap.String("policy", policy.Name),
+			zap.Int("decision", int(decision)))
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
@@ -330,6 +330,55 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 	require.Equal(t, 2, mpe.LateArrivingSpansCount, "policy was not notified of the late span")
 }
 
+func TestLateSpansFollowCachedDecision(t *testing.T) {
+	for _, decision := range []sampling.Decision{sampling.Sampled, sampling.NotSampled} {
+		// prepare
+		msp := new(consumertest.TracesSink)
+		mpe := &mockPolicyEvaluator{NextDecision: decision}
+		cache, err := newDecisionCache(10, time.Minute)
+		require.NoError(t, err)
+
+		tsp := &tailSamplingSpanProcessor{
+			ctx:             context.Background(),
+			nextConsumer:    msp,
+			maxNumTraces:    1,
+			logger:          zap.NewNop(),
+			decisionBatcher: newSyncIDBatcher(1),
+			policies:        []*Policy{{Name: "mock-policy", Evaluator: mpe, ctx: context.TODO()}},
+			deleteChan:      make(chan traceKey, 1),
+			policyTicker:    &manualTTicker{},
+			decisionCache:   cache,
+		}
+
+		firstTraceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+		secondTraceID := pdata.NewTraceID([16]byte{5, 6, 7, 8})
+
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(firstTraceID)))
+		tsp.samplingPolicyOnTick()
+		tsp.samplingPolicyOnTick()
+		require.EqualValues(t, 1, mpe.EvaluationCount)
+		spansBeforeLateArrival := msp.SpansCount()
+
+		// the second trace pushes the first one out of memory
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(secondTraceID)))
+		_, ok := tsp.idToTrace.Load(traceKey(firstTraceID.Bytes()))
+		require.False(t, ok, "the first trace should have been removed from memory")
+
+		// test
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(firstTraceID)))
+
+		// verify
+		_, ok = tsp.idToTrace.Load(traceKey(firstTraceID.Bytes()))
+		require.False(t, ok, "the late span shouldn't have started a new trace")
+		require.Equal(t, 1, mpe.LateArrivingSpansCount, "policy was not notified of the late span")
+		if decision == sampling.Sampled {
+			require.Equal(t, spansBeforeLateArrival+1, msp.SpansCount(), "late span of a sampled trace should be forwarded")
+		} else {
+			require.Equal(t, spansBeforeLateArrival, msp.SpansCount(), "late span of a non-sampled trace should be ignored")
+		}
+	}
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
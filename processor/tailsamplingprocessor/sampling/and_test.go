@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestAndEvaluatorSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	n2 := NewAlwaysSample(zap.NewNop())
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{}, "name", "value")
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	decision, err := and.Evaluate(traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestAndEvaluatorNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	n2 := NewNumericAttributeFilter(zap.NewNop(), "http.status_code", 500, 599)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{}, "name", "value")
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	decision, err := and.Evaluate(traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestAndEvaluatorWithoutSubPolicies(t *testing.T) {
+	and := NewAnd(zap.NewNop(), nil)
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{}, "name", "value")
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	decision, err := and.Evaluate(traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestOnDroppedSpans_And(t *testing.T) {
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{NewAlwaysSample(zap.NewNop())})
+	decision, err := and.OnDroppedSpans(pdata.NewTraceID([16]byte{1, 2, 3, 4}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, decision, NotSampled)
+}
+
+func TestOnLateArrivingSpans_And(t *testing.T) {
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{NewAlwaysSample(zap.NewNop())})
+	err := and.OnLateArrivingSpans(NotSampled, nil)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// SubPolicyEvalParams defines the evaluator and the share of the spans per second for a sub-policy
+// of the composite policy.
+type SubPolicyEvalParams struct {
+	// Evaluator is the sub-policy evaluator.
+	Evaluator PolicyEvaluator
+	// MaxSpansPerSecond is the number of spans per second allocated to the sub-policy.
+	MaxSpansPerSecond int64
+}
+
+type subpolicy struct {
+	evaluator         PolicyEvaluator
+	allocatedSPS      int64
+	spansInCurrSecond int64
+}
+
+type composite struct {
+	subpolicies []*subpolicy
+
+	// maxTotalSPS is the limit for the spans sampled by all the sub-policies together
+	maxTotalSPS       int64
+	currentSecond     int64
+	spansInCurrSecond int64
+
+	// nowSecond returns the current Unix second, and can be replaced in tests
+	nowSecond func() int64
+
+	logger *zap.Logger
+}
+
+var _ PolicyEvaluator = (*composite)(nil)
+
+// NewComposite creates a policy evaluator that evaluates the given sub-policies in order. The first sub-policy
+// deciding to sample a trace makes the decision, as long as the trace fits in the spans per second allocated to
+// that sub-policy. Traces that don't fit are still sampled if the total spans per second, including the capacity
+// left unused by the other sub-policies, isn't exceeded.
+func NewComposite(logger *zap.Logger, maxTotalSpansPerSecond int64, subPolicyParams []SubPolicyEvalParams) PolicyEvaluator {
+	var subpolicies []*subpolicy
+	for _, params := range subPolicyParams {
+		subpolicies = append(subpolicies, &subpolicy{
+			evaluator:    params.Evaluator,
+			allocatedSPS: params.MaxSpansPerSecond,
+		})
+	}
+
+	return &composite{
+		subpolicies: subpolicies,
+		maxTotalSPS: maxTotalSpansPerSecond,
+		nowSecond: func() int64 {
+			return time.Now().Unix()
+		},
+		logger: logger,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (c *composite) OnLateArrivingSpans(earlyDecision Decision, spans []*pdata.Span) error {
+	c.logger.Debug("Triggering action for late arriving spans in composite filter")
+	for _, sub := range c.subpolicies {
+		if err := sub.evaluator.OnLateArrivingSpans(earlyDecision, spans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (c *composite) Evaluate(traceID pdata.TraceID, trace *TraceData) (Decision, error) {
+	c.logger.Debug("Evaluating spans in composite filter")
+
+	// the counters are reset at the beginning of each second
+	currSecond := c.nowSecond()
+	if c.currentSecond != currSecond {
+		c.currentSecond = currSecond
+		c.spansInCurrSecond = 0
+		for _, sub := range c.subpolicies {
+			sub.spansInCurrSecond = 0
+		}
+	}
+
+	for _, sub := range c.subpolicies {
+		decision, err := sub.evaluator.Evaluate(traceID, trace)
+		if err != nil {
+			return Unspecified, err
+		}
+
+		if decision != Sampled {
+			continue
+		}
+
+		// the sub-policy decided to sample the trace, which has to fit in the sub-policy's allocated share,
+		// or in the capacity that isn't reserved for the other sub-policies
+		totalIfSampled := c.spansInCurrSecond + trace.SpanCount
+		if totalIfSampled > c.maxTotalSPS {
+			// no sub-policy can sample this trace anymore in the current second
+			return NotSampled, nil
+		}
+
+		subIfSampled := sub.spansInCurrSecond + trace.SpanCount
+		if subIfSampled > sub.allocatedSPS && totalIfSampled > c.maxTotalSPS-c.reservedSPS(sub) {
+			// the next sub-policies might still be able to sample this trace within their share
+			continue
+		}
+
+		sub.spansInCurrSecond = subIfSampled
+		c.spansInCurrSecond = totalIfSampled
+		return Sampled, nil
+	}
+
+	return NotSampled, nil
+}
+
+// reservedSPS returns how many spans are still reserved in the current second for the sub-policies,
+// other than the given one, that haven't used up their allocated share
+func (c *composite) reservedSPS(except *subpolicy) int64 {
+	var reserved int64
+	for _, sub := range c.subpolicies {
+		if sub != except && sub.spansInCurrSecond < sub.allocatedSPS {
+			reserved += sub.allocatedSPS - sub.spansInCurrSecond
+		}
+	}
+	return reserved
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (c *composite) OnDroppedSpans(pdata.TraceID, *TraceData) (Decision, error) {
+	c.logger.Debug("Triggering action for dropped spans in composite filter")
+	return NotSampled, nil
+}
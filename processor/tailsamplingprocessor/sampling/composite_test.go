@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+var compositeTraceID = pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+func newTestComposite(maxTotalSPS int64, params []SubPolicyEvalParams) *composite {
+	c := NewComposite(zap.NewNop(), maxTotalSPS, params).(*composite)
+	// always in the same second, unless the test says otherwise
+	c.nowSecond = func() int64 {
+		return 1
+	}
+	return c
+}
+
+func newTraceWithSpanCount(attrValue string, spanCount int64) *TraceData {
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{}, "name", attrValue)
+	trace.SpanCount = spanCount
+	return trace
+}
+
+func TestCompositeEvaluatorNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	c := newTestComposite(100, []SubPolicyEvalParams{{Evaluator: n1, MaxSpansPerSecond: 100}})
+
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("other", 1))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestCompositeEvaluatorSampledWithinAllocation(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	c := newTestComposite(100, []SubPolicyEvalParams{{Evaluator: n1, MaxSpansPerSecond: 50}})
+
+	// the first trace fits in the allocation
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 50))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestCompositeEvaluatorRespectsOtherAllocations(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	n2 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"other"})
+	c := newTestComposite(100, []SubPolicyEvalParams{
+		{Evaluator: n1, MaxSpansPerSecond: 50},
+		{Evaluator: n2, MaxSpansPerSecond: 50},
+	})
+
+	// the first sub-policy uses its whole allocation
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 50))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// the remaining capacity is reserved for the second sub-policy
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 10))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("other", 50))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestCompositeEvaluatorFallsBackToUnusedCapacity(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	n2 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"other"})
+	c := newTestComposite(100, []SubPolicyEvalParams{
+		{Evaluator: n1, MaxSpansPerSecond: 50},
+		{Evaluator: n2, MaxSpansPerSecond: 0},
+	})
+
+	// the first sub-policy goes above its allocation, using the unallocated capacity
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 80))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// the second sub-policy has no allocation, but can still use what's left
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("other", 20))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// the total is never exceeded
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("other", 1))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestCompositeEvaluatorPicksNextSubPolicyWhenOverAllocation(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"})
+	n2 := NewAlwaysSample(zap.NewNop())
+	n3 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"other"})
+	c := newTestComposite(100, []SubPolicyEvalParams{
+		{Evaluator: n1, MaxSpansPerSecond: 10},
+		{Evaluator: n2, MaxSpansPerSecond: 40},
+		{Evaluator: n3, MaxSpansPerSecond: 50},
+	})
+
+	// the first sub-policy can't sample it, but the second can
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 20))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, int64(0), c.subpolicies[0].spansInCurrSecond)
+	assert.Equal(t, int64(20), c.subpolicies[1].spansInCurrSecond)
+}
+
+func TestCompositeEvaluatorResetsEverySecond(t *testing.T) {
+	n1 := NewAlwaysSample(zap.NewNop())
+	c := newTestComposite(10, []SubPolicyEvalParams{{Evaluator: n1, MaxSpansPerSecond: 10}})
+
+	decision, err := c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 10))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 10))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	// next second
+	c.nowSecond = func() int64 {
+		return 2
+	}
+	decision, err = c.Evaluate(compositeTraceID, newTraceWithSpanCount("value", 10))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestOnDroppedSpans_Composite(t *testing.T) {
+	c := newTestComposite(10, []SubPolicyEvalParams{{Evaluator: NewAlwaysSample(zap.NewNop()), MaxSpansPerSecond: 10}})
+	decision, err := c.OnDroppedSpans(compositeTraceID, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, decision, NotSampled)
+}
+
+func TestOnLateArrivingSpans_Composite(t *testing.T) {
+	c := newTestComposite(10, []SubPolicyEvalParams{{Evaluator: NewAlwaysSample(zap.NewNop()), MaxSpansPerSecond: 10}})
+	err := c.OnLateArrivingSpans(NotSampled, nil)
+	assert.Nil(t, err)
+}
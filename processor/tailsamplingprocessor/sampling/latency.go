@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type latency struct {
+	logger    *zap.Logger
+	threshold time.Duration
+}
+
+var _ PolicyEvaluator = (*latency)(nil)
+
+// NewLatency creates a policy evaluator that samples traces lasting longer than the
+// given threshold, from the earliest span start to the latest span end.
+func NewLatency(logger *zap.Logger, threshold time.Duration) PolicyEvaluator {
+	return &latency{
+		logger:    logger,
+		threshold: threshold,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (l *latency) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	l.logger.Debug("Triggering action for late arriving spans in latency filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (l *latency) Evaluate(_ pdata.TraceID, trace *TraceData) (Decision, error) {
+	l.logger.Debug("Evaluating spans in latency filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	var minStartTime, maxEndTime pdata.TimestampUnixNano
+	sampled := hasSpanWithCondition(batches, func(span pdata.Span) bool {
+		if minStartTime == 0 || span.StartTime() < minStartTime {
+			minStartTime = span.StartTime()
+		}
+		if span.EndTime() > maxEndTime {
+			maxEndTime = span.EndTime()
+		}
+
+		return maxEndTime > minStartTime && time.Duration(maxEndTime-minStartTime) > l.threshold
+	})
+
+	if sampled {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (l *latency) OnDroppedSpans(pdata.TraceID, *TraceData) (Decision, error) {
+	l.logger.Debug("Triggering action for dropped spans in latency filter")
+	return NotSampled, nil
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type probabilisticSampler struct {
+	logger    *zap.Logger
+	threshold uint64
+	hashSalt  string
+}
+
+var _ PolicyEvaluator = (*probabilisticSampler)(nil)
+
+// NewProbabilisticSampler creates a policy evaluator that samples a percentage of
+// the traces. The decision is based on a hash of the trace ID, seeded with the given
+// salt, so collectors sharing the same configuration make the same decision for a trace.
+func NewProbabilisticSampler(logger *zap.Logger, hashSalt string, samplingPercentage float64) PolicyEvaluator {
+	return &probabilisticSampler{
+		logger:    logger,
+		threshold: calculateThreshold(samplingPercentage / 100),
+		hashSalt:  hashSalt,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (s *probabilisticSampler) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	s.logger.Debug("Triggering action for late arriving spans in probabilistic filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (s *probabilisticSampler) Evaluate(traceID pdata.TraceID, _ *TraceData) (Decision, error) {
+	s.logger.Debug("Evaluating spans in probabilistic filter")
+
+	if hashTraceID(s.hashSalt, traceID.Bytes()) <= s.threshold {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (s *probabilisticSampler) OnDroppedSpans(pdata.TraceID, *TraceData) (Decision, error) {
+	s.logger.Debug("Triggering action for dropped spans in probabilistic filter")
+	return NotSampled, nil
+}
+
+// calculateThreshold converts a ratio into a value between 0 and MaxUint64.
+func calculateThreshold(ratio float64) uint64 {
+	if ratio <= 0 {
+		return 0
+	}
+	if ratio >= 1 {
+		return math.MaxUint64
+	}
+	// the float64 representation of MaxUint64 overflows uint64, so the
+	// threshold is calculated in two halves
+	return uint64(ratio*float64(math.MaxUint64/2)) * 2
+}
+
+// hashTraceID creates a hash of the trace ID using FNV-1a, seeded with the salt.
+func hashTraceID(salt string, traceID [16]byte) uint64 {
+	hasher := fnv.New64a()
+	// the hasher never returns an error
+	_, _ = hasher.Write([]byte(salt))
+	_, _ = hasher.Write(traceID[:])
+	return hasher.Sum64()
+}
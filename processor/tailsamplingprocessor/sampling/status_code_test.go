@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestEvaluate_StatusCode(t *testing.T) {
+	filter := NewStatusCodeFilter(zap.NewNop())
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	cases := []struct {
+		Desc        string
+		StatusCodes []*pdata.StatusCode
+		Decision    Decision
+	}{
+		{
+			Desc:        "spans without status",
+			StatusCodes: []*pdata.StatusCode{nil, nil},
+			Decision:    NotSampled,
+		},
+		{
+			Desc:        "spans with OK status",
+			StatusCodes: []*pdata.StatusCode{statusCode(pdata.StatusCodeOk), statusCode(pdata.StatusCodeOk)},
+			Decision:    NotSampled,
+		},
+		{
+			Desc:        "span with an error status",
+			StatusCodes: []*pdata.StatusCode{statusCode(pdata.StatusCodeOk), statusCode(pdata.StatusCodeUnknownError)},
+			Decision:    Sampled,
+		},
+		{
+			Desc:        "span with an error status and span without status",
+			StatusCodes: []*pdata.StatusCode{nil, statusCode(pdata.StatusCodeInternalError)},
+			Decision:    Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			traces := pdata.NewTraces()
+			traces.ResourceSpans().Resize(1)
+			rs := traces.ResourceSpans().At(0)
+			rs.InstrumentationLibrarySpans().Resize(1)
+			ils := rs.InstrumentationLibrarySpans().At(0)
+			ils.Spans().Resize(len(c.StatusCodes))
+
+			for i, code := range c.StatusCodes {
+				span := ils.Spans().At(i)
+				span.SetTraceID(traceID)
+				span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, byte(i)}))
+				if code != nil {
+					span.Status().InitEmpty()
+					span.Status().SetCode(*code)
+				}
+			}
+
+			trace := &TraceData{
+				ReceivedBatches: []pdata.Traces{traces},
+			}
+
+			decision, err := filter.Evaluate(traceID, trace)
+			assert.NoError(t, err)
+			assert.Equal(t, decision, c.Decision)
+		})
+	}
+}
+
+func TestOnLateArrivingSpans_StatusCode(t *testing.T) {
+	filter := NewStatusCodeFilter(zap.NewNop())
+	err := filter.OnLateArrivingSpans(NotSampled, nil)
+	assert.Nil(t, err)
+}
+
+func TestOnDroppedSpans_StatusCode(t *testing.T) {
+	filter := NewStatusCodeFilter(zap.NewNop())
+	decision, err := filter.OnDroppedSpans(pdata.NewTraceID([16]byte{1}), &TraceData{})
+	assert.Nil(t, err)
+	assert.Equal(t, decision, NotSampled)
+}
+
+func statusCode(code pdata.StatusCode) *pdata.StatusCode {
+	return &code
+}
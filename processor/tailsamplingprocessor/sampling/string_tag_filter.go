@@ -15,32 +15,98 @@
 package sampling
 
 import (
+	"fmt"
+	"regexp"
+
+	lru "github.com/hashicorp/golang-lru"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
 
+// defaultCacheMaxSize is the number of match results kept for regex matching when
+// no cache size is given.
+const defaultCacheMaxSize = 128
+
 type stringAttributeFilter struct {
 	key    string
 	values map[string]struct{}
-	logger *zap.Logger
+	// regexes is only set when regex matching is enabled, replacing values
+	regexes []*regexp.Regexp
+	// matchCache holds the result of matching recently seen attribute values against the regexes
+	matchCache  *lru.Cache
+	invertMatch bool
+	logger      *zap.Logger
 }
 
 var _ PolicyEvaluator = (*stringAttributeFilter)(nil)
 
+// StringAttributeFilterOptions holds the optional settings of the string attribute filter.
+type StringAttributeFilterOptions struct {
+	// EnabledRegexMatching treats the values as regular expressions instead of exact matches.
+	EnabledRegexMatching bool
+	// CacheMaxSize is the number of attribute values whose regex match result is kept
+	// in an LRU cache. Defaults to 128 when regex matching is enabled.
+	CacheMaxSize int
+	// InvertMatch samples the traces in which no attribute matches the values.
+	InvertMatch bool
+}
+
 // NewStringAttributeFilter creates a policy evaluator that samples all traces with
-// the given attribute in the given numeric range.
+// the given attribute equal to one of the given values.
 func NewStringAttributeFilter(logger *zap.Logger, key string, values []string) PolicyEvaluator {
+	return &stringAttributeFilter{
+		key:    key,
+		values: valuesMap(values),
+		logger: logger,
+	}
+}
+
+// NewStringAttributeFilterWithOptions creates a policy evaluator that samples all traces with
+// the given attribute matching one of the given values, according to the given options.
+func NewStringAttributeFilterWithOptions(logger *zap.Logger, key string, values []string, options StringAttributeFilterOptions) (PolicyEvaluator, error) {
+	saf := &stringAttributeFilter{
+		key:         key,
+		invertMatch: options.InvertMatch,
+		logger:      logger,
+	}
+
+	if !options.EnabledRegexMatching {
+		saf.values = valuesMap(values)
+		return saf, nil
+	}
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q for the attribute %q: %w", value, key, err)
+		}
+		saf.regexes = append(saf.regexes, re)
+	}
+
+	cacheMaxSize := options.CacheMaxSize
+	if cacheMaxSize <= 0 {
+		cacheMaxSize = defaultCacheMaxSize
+	}
+	cache, err := lru.New(cacheMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	saf.matchCache = cache
+
+	return saf, nil
+}
+
+func valuesMap(values []string) map[string]struct{} {
 	valuesMap := make(map[string]struct{})
 	for _, value := range values {
 		if value != "" {
 			valuesMap[value] = struct{}{}
 		}
 	}
-	return &stringAttributeFilter{
-		key:    key,
-		values: valuesMap,
-		logger: logger,
-	}
+	return valuesMap
 }
 
 // OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
@@ -58,6 +124,16 @@ func (saf *stringAttributeFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (D
 	trace.Lock()
 	batches := trace.ReceivedBatches
 	trace.Unlock()
+
+	matched := saf.hasMatchingAttribute(batches)
+	// when inverted, only the traces without any match are sampled
+	if matched != saf.invertMatch {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (saf *stringAttributeFilter) hasMatchingAttribute(batches []pdata.Traces) bool {
 	for _, batch := range batches {
 		rspans := batch.ResourceSpans()
 
@@ -70,8 +146,8 @@ func (saf *stringAttributeFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (D
 			resource := rs.Resource()
 			if !resource.IsNil() {
 				if v, ok := resource.Attributes().Get(saf.key); ok {
-					if _, ok := saf.values[v.StringVal()]; ok {
-						return Sampled, nil
+					if saf.matches(v.StringVal()) {
+						return true
 					}
 				}
 			}
@@ -89,10 +165,8 @@ func (saf *stringAttributeFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (D
 					}
 					if v, ok := span.Attributes().Get(saf.key); ok {
 						truncableStr := v.StringVal()
-						if len(truncableStr) > 0 {
-							if _, ok := saf.values[truncableStr]; ok {
-								return Sampled, nil
-							}
+						if len(truncableStr) > 0 && saf.matches(truncableStr) {
+							return true
 						}
 					}
 
@@ -100,7 +174,29 @@ func (saf *stringAttributeFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (D
 			}
 		}
 	}
-	return NotSampled, nil
+	return false
+}
+
+// matches returns whether the given attribute value matches one of the configured values.
+func (saf *stringAttributeFilter) matches(value string) bool {
+	if saf.matchCache == nil {
+		_, ok := saf.values[value]
+		return ok
+	}
+
+	if cached, ok := saf.matchCache.Get(value); ok {
+		return cached.(bool)
+	}
+
+	matched := false
+	for _, re := range saf.regexes {
+		if re.MatchString(value) {
+			matched = true
+			break
+		}
+	}
+	saf.matchCache.Add(value, matched)
+	return matched
 }
 
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
@@ -15,11 +15,13 @@
 package sampling
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
@@ -75,6 +77,127 @@ func TestStringTagFilter(t *testing.T) {
 	}
 }
 
+func TestStringTagFilterWithRegexMatching(t *testing.T) {
+	var empty = map[string]pdata.AttributeValue{}
+	filter, err := NewStringAttributeFilterWithOptions(zap.NewNop(), "example", []string{"^v[a-z]+e$", "prefix.*"}, StringAttributeFilterOptions{
+		EnabledRegexMatching: true,
+		CacheMaxSize:         2,
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		Desc     string
+		Trace    *TraceData
+		Decision Decision
+	}{
+		{
+			Desc:     "nonmatching node attribute value",
+			Trace:    newTraceStringAttrs(map[string]pdata.AttributeValue{"example": pdata.NewAttributeValueString("non_matching")}, "", ""),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "matching node attribute",
+			Trace:    newTraceStringAttrs(map[string]pdata.AttributeValue{"example": pdata.NewAttributeValueString("value")}, "", ""),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "nonmatching span attribute value",
+			Trace:    newTraceStringAttrs(empty, "example", "values"),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "matching span attribute",
+			Trace:    newTraceStringAttrs(empty, "example", "vae"),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "span attribute matching the second expression",
+			Trace:    newTraceStringAttrs(empty, "example", "prefix-value"),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "cached matching span attribute",
+			Trace:    newTraceStringAttrs(empty, "example", "vae"),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "cached nonmatching span attribute",
+			Trace:    newTraceStringAttrs(empty, "example", "values"),
+			Decision: NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			decision, err := filter.Evaluate(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, decision, c.Decision)
+		})
+	}
+
+	// the cache never holds more entries than allowed
+	assert.LessOrEqual(t, filter.(*stringAttributeFilter).matchCache.Len(), 2)
+}
+
+func TestStringTagFilterWithInvertMatch(t *testing.T) {
+	var empty = map[string]pdata.AttributeValue{}
+
+	for _, regexEnabled := range []bool{false, true} {
+		filter, err := NewStringAttributeFilterWithOptions(zap.NewNop(), "http.target", []string{"/health"}, StringAttributeFilterOptions{
+			EnabledRegexMatching: regexEnabled,
+			InvertMatch:          true,
+		})
+		require.NoError(t, err)
+
+		cases := []struct {
+			Desc     string
+			Trace    *TraceData
+			Decision Decision
+		}{
+			{
+				Desc:     "without the attribute",
+				Trace:    newTraceStringAttrs(empty, "non_matching", "/health"),
+				Decision: Sampled,
+			},
+			{
+				Desc:     "nonmatching node attribute value",
+				Trace:    newTraceStringAttrs(map[string]pdata.AttributeValue{"http.target": pdata.NewAttributeValueString("/checkout")}, "", ""),
+				Decision: Sampled,
+			},
+			{
+				Desc:     "matching node attribute",
+				Trace:    newTraceStringAttrs(map[string]pdata.AttributeValue{"http.target": pdata.NewAttributeValueString("/health")}, "", ""),
+				Decision: NotSampled,
+			},
+			{
+				Desc:     "nonmatching span attribute value",
+				Trace:    newTraceStringAttrs(empty, "http.target", "/checkout"),
+				Decision: Sampled,
+			},
+			{
+				Desc:     "matching span attribute",
+				Trace:    newTraceStringAttrs(empty, "http.target", "/health"),
+				Decision: NotSampled,
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("%s (regex: %t)", c.Desc, regexEnabled), func(t *testing.T) {
+				decision, err := filter.Evaluate(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), c.Trace)
+				assert.NoError(t, err)
+				assert.Equal(t, decision, c.Decision)
+			})
+		}
+	}
+}
+
+func TestStringTagFilterWithInvalidRegex(t *testing.T) {
+	_, err := NewStringAttributeFilterWithOptions(zap.NewNop(), "example", []string{"("}, StringAttributeFilterOptions{
+		EnabledRegexMatching: true,
+	})
+	assert.Error(t, err)
+}
+
 func newTraceStringAttrs(nodeAttrs map[string]pdata.AttributeValue, spanAttrKey string, spanAttrValue string) *TraceData {
 	var traceBatches []pdata.Traces
 	traces := pdata.NewTraces()
@@ -26,6 +26,7 @@ var (
 	// configuration.
 	parserMap = map[string]func() ParserConfig{
 		"plaintext": plaintextDefaultConfig,
+		"pickle":    pickleDefaultConfig,
 		"regex":     regexDefaultConfig,
 	}
 
@@ -66,6 +66,15 @@ config:
 				Config: &RegexParserConfig{},
 			},
 		},
+		{
+			name: "default_pickle",
+			yaml: `type: pickle`,
+			cfg:  Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
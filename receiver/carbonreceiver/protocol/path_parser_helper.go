@@ -118,36 +118,45 @@ func (pph *PathParserHelper) Parse(line string) (*metricspb.Metric, error) {
 		return nil, fmt.Errorf("invalid carbon metric time [%s]: %v", line, err)
 	}
 
-	var metricType metricspb.MetricDescriptor_Type
 	point := metricspb.Point{
 		Timestamp: convertUnixSec(unixTime),
 	}
 	intVal, err := strconv.ParseInt(valueStr, 10, 64)
 	if err == nil {
-		if parsedPath.MetricType == CumulativeMetricType {
-			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
-		} else {
-			metricType = metricspb.MetricDescriptor_GAUGE_INT64
-		}
 		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
 	} else {
 		dblVal, err := strconv.ParseFloat(valueStr, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid carbon metric value [%s]: %v", line, err)
 		}
-		if parsedPath.MetricType == CumulativeMetricType {
-			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
-		} else {
-			metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
-		}
 		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
 	}
 
-	metric := buildMetricForSinglePoint(
+	return buildMetricForParsedPath(parsedPath, &point), nil
+}
+
+// buildMetricForParsedPath builds the metric of the parsed path with the given
+// point. The metric is an int64 or double metric according to the point value and
+// a gauge unless the path requires a cumulative metric.
+func buildMetricForParsedPath(parsedPath ParsedPath, point *metricspb.Point) *metricspb.Metric {
+	_, isInt := point.Value.(*metricspb.Point_Int64Value)
+
+	var metricType metricspb.MetricDescriptor_Type
+	switch {
+	case isInt && parsedPath.MetricType == CumulativeMetricType:
+		metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+	case isInt:
+		metricType = metricspb.MetricDescriptor_GAUGE_INT64
+	case parsedPath.MetricType == CumulativeMetricType:
+		metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
+	default:
+		metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
+	}
+
+	return buildMetricForSinglePoint(
 		parsedPath.MetricName,
 		metricType,
 		parsedPath.LabelKeys,
 		parsedPath.LabelValues,
-		&point)
-	return metric, nil
+		point)
 }
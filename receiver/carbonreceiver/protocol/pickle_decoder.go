@@ -0,0 +1,639 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Pickle opcodes supported by the decoder. Only the opcodes needed to represent
+// lists, tuples, strings and numbers are supported, any opcode that could
+// import or call Python code (GLOBAL, REDUCE, BUILD, INST, OBJ, etc) is rejected.
+// See https://github.com/python/cpython/blob/master/Lib/pickletools.py for the
+// description of the opcodes.
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+	opGet            = 'g'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opAppends        = 'e'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opBinFloat       = 'G'
+	opBinBytes       = 'B'
+	opShortBinBytes  = 'C'
+
+	// Protocol 2 and above.
+	opProto    = '\x80'
+	opTuple1   = '\x85'
+	opTuple2   = '\x86'
+	opTuple3   = '\x87'
+	opNewTrue  = '\x88'
+	opNewFalse = '\x89'
+	opLong1    = '\x8a'
+	opLong4    = '\x8b'
+
+	// Protocol 4 and above.
+	opShortBinUnicode = '\x8c'
+	opBinUnicode8     = '\x8d'
+	opBinBytes8       = '\x8e'
+	opMemoize         = '\x94'
+	opFrame           = '\x95'
+)
+
+const (
+	// maxPickleDepth is the maximum nesting of the lists and tuples of a pickle.
+	// Carbon payloads only need a depth of 3: a list of tuples of tuples.
+	maxPickleDepth = 32
+	// maxPickleSize is the maximum size of a decoded list or tuple, counting the
+	// values shared through the memo each time they appear and strings by their
+	// length. Without it a small payload could reference the same values enough
+	// times to exhaust the memory or time of anything walking the decoded value.
+	maxPickleSize = 8 * 1024 * 1024
+)
+
+var (
+	errPickleStackUnderflow = errors.New("pickle stack underflow")
+	errPickleNoMark         = errors.New("pickle mark not found")
+	errPickleTooDeep        = fmt.Errorf("pickle nesting exceeds the maximum depth of %d", maxPickleDepth)
+	errPickleTooLarge       = fmt.Errorf("pickle exceeds the maximum decoded size of %d", maxPickleSize)
+	errPickleNestedList     = errors.New("pickle cannot append to a list nested in another container")
+)
+
+// pickleMark is pushed on the stack by the MARK opcode.
+type pickleMark struct{}
+
+// pickleContainer tracks the size and depth of a list or tuple, so that the
+// decoder can enforce maxPickleSize and maxPickleDepth.
+type pickleContainer struct {
+	size  int
+	depth int
+	// nested is set once the container is an item of another container. A
+	// nested list can no longer be appended to, otherwise the size of the
+	// containers holding it would be outdated and a list could hold itself.
+	nested bool
+}
+
+// pickleList is a list being built by the pickle, kept as a pointer so that the
+// items appended to it are visible through the memo.
+type pickleList struct {
+	pickleContainer
+	items []interface{}
+}
+
+// pickleTuple is an immutable tuple of the pickle.
+type pickleTuple struct {
+	pickleContainer
+	items []interface{}
+}
+
+// pickleDecoder decodes a pickle into Go values without executing any code:
+// lists are decoded as *pickleList, tuples as *pickleTuple, strings and bytes as
+// string, integers as int64 (or *big.Int if they don't fit), floats as float64,
+// booleans as bool and None as nil.
+type pickleDecoder struct {
+	data  []byte
+	pos   int
+	stack []interface{}
+	memo  map[int64]interface{}
+}
+
+// decodePickle decodes the given pickle payload and returns the resulting value.
+func decodePickle(payload []byte) (interface{}, error) {
+	d := &pickleDecoder{
+		data: payload,
+		memo: make(map[int64]interface{}),
+	}
+	return d.decode()
+}
+
+func (d *pickleDecoder) decode() (interface{}, error) {
+	for {
+		op, err := d.readByte()
+		if err != nil {
+			return nil, errors.New("pickle ended without STOP opcode")
+		}
+
+		if op == opStop {
+			v, err := d.pop()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := v.(pickleMark); ok {
+				return nil, errors.New("pickle stack has a mark at STOP")
+			}
+			return v, nil
+		}
+
+		if err := d.execute(op); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *pickleDecoder) execute(op byte) error {
+	switch op {
+	case opProto:
+		_, err := d.readByte()
+		return err
+	case opFrame:
+		// Frames only give hints about the size of the data that follows.
+		_, err := d.readBytes(8)
+		return err
+
+	case opMark:
+		d.push(pickleMark{})
+	case opPop:
+		_, err := d.pop()
+		return err
+	case opPopMark:
+		_, err := d.popMark()
+		return err
+	case opDup:
+		if len(d.stack) == 0 {
+			return errPickleStackUnderflow
+		}
+		d.push(d.stack[len(d.stack)-1])
+
+	case opNone:
+		d.push(nil)
+	case opNewTrue:
+		d.push(true)
+	case opNewFalse:
+		d.push(false)
+
+	case opInt:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		// Protocol 0 encodes booleans as "I00" and "I01".
+		switch line {
+		case "00":
+			d.push(false)
+			return nil
+		case "01":
+			d.push(true)
+			return nil
+		}
+		v, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid pickle INT %q: %v", line, err)
+		}
+		d.push(v)
+	case opLong:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		v, ok := new(big.Int).SetString(strings.TrimSuffix(line, "L"), 10)
+		if !ok {
+			return fmt.Errorf("invalid pickle LONG %q", line)
+		}
+		d.push(normalizeBigInt(v))
+	case opBinInt:
+		b, err := d.readBytes(4)
+		if err != nil {
+			return err
+		}
+		d.push(int64(int32(binary.LittleEndian.Uint32(b))))
+	case opBinInt1:
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		d.push(int64(b))
+	case opBinInt2:
+		b, err := d.readBytes(2)
+		if err != nil {
+			return err
+		}
+		d.push(int64(binary.LittleEndian.Uint16(b)))
+	case opLong1:
+		n, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		return d.pushLong(int64(n))
+	case opLong4:
+		n, err := d.readLength(4)
+		if err != nil {
+			return err
+		}
+		return d.pushLong(n)
+	case opFloat:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		v, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return fmt.Errorf("invalid pickle FLOAT %q: %v", line, err)
+		}
+		d.push(v)
+	case opBinFloat:
+		b, err := d.readBytes(8)
+		if err != nil {
+			return err
+		}
+		d.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	case opString:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		v, err := unquotePickleString(line)
+		if err != nil {
+			return err
+		}
+		d.push(v)
+	case opUnicode:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		d.push(line)
+	case opShortBinString, opShortBinBytes, opShortBinUnicode:
+		n, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		return d.pushString(int64(n))
+	case opBinString, opBinBytes, opBinUnicode:
+		n, err := d.readLength(4)
+		if err != nil {
+			return err
+		}
+		return d.pushString(n)
+	case opBinUnicode8, opBinBytes8:
+		n, err := d.readLength(8)
+		if err != nil {
+			return err
+		}
+		return d.pushString(n)
+
+	case opEmptyList:
+		d.push(&pickleList{pickleContainer: pickleContainer{size: 1, depth: 1}})
+	case opList:
+		items, err := d.popMark()
+		if err != nil {
+			return err
+		}
+		c, err := newPickleContainer(items)
+		if err != nil {
+			return err
+		}
+		d.push(&pickleList{pickleContainer: c, items: items})
+	case opAppend:
+		v, err := d.pop()
+		if err != nil {
+			return err
+		}
+		return d.appendToList(v)
+	case opAppends:
+		items, err := d.popMark()
+		if err != nil {
+			return err
+		}
+		return d.appendToList(items...)
+	case opEmptyTuple:
+		return d.pushTuple(nil)
+	case opTuple:
+		items, err := d.popMark()
+		if err != nil {
+			return err
+		}
+		return d.pushTuple(items)
+	case opTuple1, opTuple2, opTuple3:
+		n := int(op-opTuple1) + 1
+		if len(d.stack) < n {
+			return errPickleStackUnderflow
+		}
+		items := make([]interface{}, n)
+		copy(items, d.stack[len(d.stack)-n:])
+		d.stack = d.stack[:len(d.stack)-n]
+		return d.pushTuple(items)
+
+	case opPut:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		idx, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid pickle PUT %q: %v", line, err)
+		}
+		return d.put(idx)
+	case opBinPut:
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		return d.put(int64(b))
+	case opLongBinPut:
+		idx, err := d.readLength(4)
+		if err != nil {
+			return err
+		}
+		return d.put(idx)
+	case opMemoize:
+		return d.put(int64(len(d.memo)))
+	case opGet:
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		idx, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid pickle GET %q: %v", line, err)
+		}
+		return d.get(idx)
+	case opBinGet:
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		return d.get(int64(b))
+	case opLongBinGet:
+		idx, err := d.readLength(4)
+		if err != nil {
+			return err
+		}
+		return d.get(idx)
+
+	default:
+		return fmt.Errorf("unsupported pickle opcode 0x%02x", op)
+	}
+
+	return nil
+}
+
+func (d *pickleDecoder) push(v interface{}) {
+	d.stack = append(d.stack, v)
+}
+
+func (d *pickleDecoder) pop() (interface{}, error) {
+	if len(d.stack) == 0 {
+		return nil, errPickleStackUnderflow
+	}
+	v := d.stack[len(d.stack)-1]
+	d.stack = d.stack[:len(d.stack)-1]
+	return v, nil
+}
+
+// popMark pops the items pushed after the topmost mark, and the mark itself.
+func (d *pickleDecoder) popMark() ([]interface{}, error) {
+	for i := len(d.stack) - 1; i >= 0; i-- {
+		if _, ok := d.stack[i].(pickleMark); ok {
+			items := make([]interface{}, len(d.stack)-i-1)
+			copy(items, d.stack[i+1:])
+			d.stack = d.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errPickleNoMark
+}
+
+func (d *pickleDecoder) appendToList(items ...interface{}) error {
+	if len(d.stack) == 0 {
+		return errPickleStackUnderflow
+	}
+	l, ok := d.stack[len(d.stack)-1].(*pickleList)
+	if !ok {
+		return fmt.Errorf("pickle cannot append to %T", d.stack[len(d.stack)-1])
+	}
+	if l.nested {
+		return errPickleNestedList
+	}
+	for _, item := range items {
+		if item == interface{}(l) {
+			return errors.New("pickle cannot append a list to itself")
+		}
+	}
+
+	c, err := newPickleContainer(items)
+	if err != nil {
+		return err
+	}
+	// The new items are accounted on top of the existing ones, c.size already
+	// includes the list itself.
+	if l.size+c.size-1 > maxPickleSize {
+		return errPickleTooLarge
+	}
+	l.size += c.size - 1
+	if c.depth > l.depth {
+		l.depth = c.depth
+	}
+	l.items = append(l.items, items...)
+	return nil
+}
+
+func (d *pickleDecoder) pushTuple(items []interface{}) error {
+	c, err := newPickleContainer(items)
+	if err != nil {
+		return err
+	}
+	d.push(&pickleTuple{pickleContainer: c, items: items})
+	return nil
+}
+
+// newPickleContainer computes the size and depth of a container with the given
+// items, marking the lists and tuples among them as nested.
+func newPickleContainer(items []interface{}) (pickleContainer, error) {
+	c := pickleContainer{size: 1, depth: 1}
+	for _, item := range items {
+		var itemSize, itemDepth int
+		switch v := item.(type) {
+		case *pickleList:
+			v.nested = true
+			itemSize, itemDepth = v.size, v.depth
+		case *pickleTuple:
+			v.nested = true
+			itemSize, itemDepth = v.size, v.depth
+		case pickleMark:
+			return c, errors.New("pickle mark cannot be an item of a container")
+		case string:
+			itemSize = 1 + len(v)
+		default:
+			itemSize = 1
+		}
+
+		// Checked item by item so that the sum cannot overflow.
+		if itemSize > maxPickleSize-c.size {
+			return c, errPickleTooLarge
+		}
+		c.size += itemSize
+		if itemDepth+1 > c.depth {
+			c.depth = itemDepth + 1
+		}
+	}
+	if c.depth > maxPickleDepth {
+		return c, errPickleTooDeep
+	}
+	return c, nil
+}
+
+func (d *pickleDecoder) put(idx int64) error {
+	if len(d.stack) == 0 {
+		return errPickleStackUnderflow
+	}
+	d.memo[idx] = d.stack[len(d.stack)-1]
+	return nil
+}
+
+func (d *pickleDecoder) get(idx int64) error {
+	v, ok := d.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo key %d not found", idx)
+	}
+	d.push(v)
+	return nil
+}
+
+func (d *pickleDecoder) pushString(n int64) error {
+	b, err := d.readBytes(n)
+	if err != nil {
+		return err
+	}
+	d.push(string(b))
+	return nil
+}
+
+// pushLong pushes a little-endian two's complement integer of n bytes.
+func (d *pickleDecoder) pushLong(n int64) error {
+	b, err := d.readBytes(n)
+	if err != nil {
+		return err
+	}
+	// Reverse to big-endian, the byte order of big.Int.
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	v := new(big.Int).SetBytes(be)
+	if len(be) > 0 && be[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(be))*8))
+	}
+	d.push(normalizeBigInt(v))
+	return nil
+}
+
+// readLength reads an unsigned little-endian length of the given number of bytes.
+// Lengths larger than the payload are rejected by readBytes, so a malformed length
+// cannot trigger a large allocation.
+func (d *pickleDecoder) readLength(size int64) (int64, error) {
+	b, err := d.readBytes(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	if size == 4 {
+		n = uint64(binary.LittleEndian.Uint32(b))
+	} else {
+		n = binary.LittleEndian.Uint64(b)
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("pickle length %d is too large", n)
+	}
+	return int64(n), nil
+}
+
+func (d *pickleDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+// readBytes returns the next n bytes of the payload, without copying them.
+func (d *pickleDecoder) readBytes(n int64) ([]byte, error) {
+	if n < 0 || n > int64(len(d.data)-d.pos) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// readLine returns the text up to the next newline, used by the protocol 0 opcodes.
+func (d *pickleDecoder) readLine() (string, error) {
+	idx := bytes.IndexByte(d.data[d.pos:], '\n')
+	if idx < 0 {
+		return "", io.ErrUnexpectedEOF
+	}
+	line := string(d.data[d.pos : d.pos+idx])
+	d.pos += idx + 1
+	return line, nil
+}
+
+// unquotePickleString removes the quotes of the Python string representation used
+// by the STRING opcode and interprets its escape sequences.
+func unquotePickleString(s string) (string, error) {
+	if len(s) < 2 || s[0] != s[len(s)-1] || (s[0] != '\'' && s[0] != '"') {
+		return "", fmt.Errorf("invalid pickle STRING %q", s)
+	}
+	quoted := s[1 : len(s)-1]
+	if !strings.Contains(quoted, "\\") {
+		return quoted, nil
+	}
+	// Python and Go escape sequences are close enough for metric paths, only the
+	// quotes need to be adjusted for strconv.Unquote.
+	v, err := strconv.Unquote(`"` + strings.ReplaceAll(strings.ReplaceAll(quoted, `\'`, `'`), `"`, `\"`) + `"`)
+	if err != nil {
+		return "", fmt.Errorf("invalid pickle STRING %q: %v", s, err)
+	}
+	return v, nil
+}
+
+// normalizeBigInt returns the value as an int64 if it fits in one.
+func normalizeBigInt(v *big.Int) interface{} {
+	if v.IsInt64() {
+		return v.Int64()
+	}
+	return v
+}
@@ -0,0 +1,252 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodePickle(t *testing.T) {
+	// The payloads were generated with pickle.dumps in Python for the value:
+	// [("test.metric;key=value", (1600000000, 42)),
+	//  ("test.double", (1600000000.5, 1.5)),
+	//  ("test.string", ("1600000000", "7"))]
+	want := []interface{}{
+		[]interface{}{"test.metric;key=value", []interface{}{int64(1600000000), int64(42)}},
+		[]interface{}{"test.double", []interface{}{1600000000.5, 1.5}},
+		[]interface{}{"test.string", []interface{}{"1600000000", "7"}},
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:    "protocol_0",
+			payload: "(lp0\n(Vtest.metric;key=value\np1\n(I1600000000\nI42\ntp2\ntp3\na(Vtest.double\np4\n(F1600000000.5\nF1.5\ntp5\ntp6\na(Vtest.string\np7\n(V1600000000\np8\nV7\np9\ntp10\ntp11\na.",
+			want:    want,
+		},
+		{
+			name:    "protocol_2",
+			payload: "\x80\x02]q\x00(X\x15\x00\x00\x00test.metric;key=valueq\x01J\x00\x10^_K*\x86q\x02\x86q\x03X\x0b\x00\x00\x00test.doubleq\x04GA\xd7\xd7\x84\x00 \x00\x00G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x05\x86q\x06X\x0b\x00\x00\x00test.stringq\x07X\n\x00\x00\x001600000000q\x08X\x01\x00\x00\x007q\x09\x86q\n\x86q\x0be.",
+			want:    want,
+		},
+		{
+			name:    "protocol_4",
+			payload: "\x80\x04\x95o\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x15test.metric;key=value\x94J\x00\x10^_K*\x86\x94\x86\x94\x8c\x0btest.double\x94GA\xd7\xd7\x84\x00 \x00\x00G?\xf8\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x0btest.string\x94\x8c\n1600000000\x94\x8c\x017\x94\x86\x94\x86\x94e.",
+			want:    want,
+		},
+		{
+			name:    "python2_string",
+			payload: "(lp0\n(S'test.metric'\np1\n(I1600000000\nF42.5\ntp2\ntp3\na.",
+			want: []interface{}{
+				[]interface{}{"test.metric", []interface{}{int64(1600000000), 42.5}},
+			},
+		},
+		{
+			name:    "negative_long",
+			payload: "\x80\x02]q\x00X\x03\x00\x00\x00negq\x01J\x00\x10^_\x8a\x06\x00\x00\x00\x00\x00\xff\x86q\x02\x86q\x03a.",
+			want: []interface{}{
+				[]interface{}{"neg", []interface{}{int64(1600000000), int64(-1 << 40)}},
+			},
+		},
+		{
+			name:    "memo_get",
+			payload: "\x80\x02]q\x00(X\x01\x00\x00\x00aq\x01K\x01K\x02\x86q\x02\x86q\x03h\x03e.",
+			want: []interface{}{
+				[]interface{}{"a", []interface{}{int64(1), int64(2)}},
+				[]interface{}{"a", []interface{}{int64(1), int64(2)}},
+			},
+		},
+		{
+			name:    "dict_is_not_supported",
+			payload: "\x80\x02}q\x00X\x01\x00\x00\x00aq\x01K\x01s.",
+			wantErr: true,
+		},
+		{
+			// Generated from an object with __reduce__ returning (os.system, ("true",)).
+			name:    "global_and_reduce_are_rejected",
+			payload: "\x80\x02]q\x00cposix\nsystem\nq\x01X\x04\x00\x00\x00trueq\x02\x85q\x03Rq\x04a.",
+			wantErr: true,
+		},
+		{
+			name:    "missing_stop",
+			payload: "\x80\x02]q\x00",
+			wantErr: true,
+		},
+		{
+			name:    "truncated_string",
+			payload: "\x80\x02X\xff\x00\x00\x00abc.",
+			wantErr: true,
+		},
+		{
+			name:    "huge_string_length",
+			payload: "\x80\x02X\xff\xff\xff\xff.",
+			wantErr: true,
+		},
+		{
+			name:    "stack_underflow",
+			payload: "a.",
+			wantErr: true,
+		},
+		{
+			name:    "missing_mark",
+			payload: "K\x01t.",
+			wantErr: true,
+		},
+		{
+			name:    "unknown_memo_key",
+			payload: "h\x05.",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			payload: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePickle([]byte(tt.payload))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, plainPickle(got))
+		})
+	}
+}
+
+func Test_decodePickle_bigInt(t *testing.T) {
+	want := new(big.Int).Lsh(big.NewInt(1), 70)
+	for _, payload := range []string{
+		"(lp0\n(Vtest.big\np1\n(I1600000000\nL1180591620717411303424L\ntp2\ntp3\na.",
+		"\x80\x02]q\x00X\x08\x00\x00\x00test.bigq\x01J\x00\x10^_\x8a\x09\x00\x00\x00\x00\x00\x00\x00\x00@\x86q\x02\x86q\x03a.",
+	} {
+		got, err := decodePickle([]byte(payload))
+		require.NoError(t, err)
+		value := plainPickle(got).([]interface{})[0].([]interface{})[1].([]interface{})[1]
+		require.IsType(t, &big.Int{}, value)
+		assert.Equal(t, want.String(), value.(*big.Int).String())
+	}
+
+	got, err := decodePickle([]byte("\x8a\x09\x00\x00\x00\x00\x00\x00\x00\x00\xc0."))
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Neg(want).String(), got.(*big.Int).String())
+}
+
+func Test_decodePickle_limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		wantErr error
+	}{
+		{
+			// T0 = ("x",), then T(n+1) = (Tn, Tn) through the memo: a few bytes
+			// per level that double the decoded size.
+			name:    "shared_tuples",
+			payload: sharedTuplesPickle(64),
+			wantErr: errPickleTooLarge,
+		},
+		{
+			// A tuple referencing the same 64KiB string through the memo
+			// 1024 times.
+			name:    "shared_string",
+			payload: sharedStringPickle(64*1024, 1024),
+			wantErr: errPickleTooLarge,
+		},
+		{
+			name:    "too_deep",
+			payload: append(append([]byte("N"), bytes.Repeat([]byte{opTuple1}, maxPickleDepth+1)...), opStop),
+			wantErr: errPickleTooDeep,
+		},
+		{
+			// l = []; memo[0] = l; l.append(l)
+			name:    "list_appended_to_itself",
+			payload: []byte("]q\x00h\x00a."),
+		},
+		{
+			// l = []; memo[0] = l; t = (l,); l.append(1)
+			name:    "append_to_nested_list",
+			payload: []byte("]q\x00h\x00\x850h\x00K\x01a."),
+			wantErr: errPickleNestedList,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePickle(tt.payload)
+			require.Error(t, err)
+			assert.Nil(t, got)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// sharedTuplesPickle returns a pickle of depth tuples, each holding twice the
+// previous one, whose decoded size doubles at each level.
+func sharedTuplesPickle(depth int) []byte {
+	var b bytes.Buffer
+	b.WriteString("\x80\x02X\x01\x00\x00\x00x\x85q\x00")
+	for i := 0; i < depth; i++ {
+		b.Write([]byte{opBinGet, 0, opBinGet, 0, opTuple2, opBinPut, 0})
+	}
+	b.WriteByte(opStop)
+	return b.Bytes()
+}
+
+// sharedStringPickle returns a pickle of a tuple referencing count times the
+// same string of the given length.
+func sharedStringPickle(length, count int) []byte {
+	var b bytes.Buffer
+	b.WriteString("\x80\x02(")
+	b.WriteByte(opBinUnicode)
+	_ = binary.Write(&b, binary.LittleEndian, uint32(length))
+	b.Write(bytes.Repeat([]byte("a"), length))
+	b.Write([]byte{opBinPut, 0})
+	for i := 1; i < count; i++ {
+		b.Write([]byte{opBinGet, 0})
+	}
+	b.Write([]byte{opTuple, opStop})
+	return b.Bytes()
+}
+
+// plainPickle converts the decoded lists and tuples to []interface{}.
+func plainPickle(v interface{}) interface{} {
+	var items []interface{}
+	switch c := v.(type) {
+	case *pickleList:
+		items = c.items
+	case *pickleTuple:
+		items = c.items
+	default:
+		return v
+	}
+	plain := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		plain = append(plain, plainPickle(item))
+	}
+	return plain
+}
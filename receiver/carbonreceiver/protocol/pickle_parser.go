@@ -0,0 +1,196 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+// BatchParser is implemented by the parsers of protocols that carry several
+// metrics in each message, like the pickle protocol.
+type BatchParser interface {
+	Parser
+
+	// ParseBatch decodes a message with several Carbon datapoints and transforms
+	// them to the collector metric format. The metrics of the valid datapoints
+	// are returned together with an error for each invalid datapoint. The
+	// returned error is not nil when the message itself cannot be decoded.
+	ParseBatch(payload []byte) (metrics []*metricspb.Metric, invalid []error, err error)
+}
+
+// PickleConfig holds the configuration for the pickle parser, which receives
+// the pickled lists of datapoints sent by Carbon relays, see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+//
+// The <metric_path> of the datapoints is handled as by the "plaintext" parser
+// unless regular expression rules are configured, in which case it is handled as
+// by the "regex" parser (see RegexParserConfig for the details of the rules).
+type PickleConfig struct {
+	// Rules contains the optional regular expression rules to be applied to the
+	// metric paths.
+	Rules []*RegexRule `mapstructure:"rules"`
+
+	// MetricNameSeparator is used when joining the name prefix of a rule with the
+	// captures that start with the prefix "name_".
+	MetricNameSeparator string `mapstructure:"name_separator"`
+}
+
+var _ (ParserConfig) = (*PickleConfig)(nil)
+
+// BuildParser creates a new Parser instance that receives pickled Carbon data.
+func (pc *PickleConfig) BuildParser() (Parser, error) {
+	if pc == nil {
+		return nil, errors.New("nil receiver on PickleConfig.BuildParser")
+	}
+
+	var pathParser PathParser = &PlaintextPathParser{}
+	if len(pc.Rules) > 0 {
+		if err := compileRegexRules(pc.Rules); err != nil {
+			return nil, err
+		}
+		pathParser = &regexPathParser{
+			rules:               pc.Rules,
+			metricNameSeparator: pc.MetricNameSeparator,
+		}
+	}
+
+	return &pickleParser{
+		PathParserHelper: PathParserHelper{
+			pathParser: pathParser,
+		},
+	}, nil
+}
+
+// pickleParser decodes the pickled list of datapoints, each in the format
+//
+//	(<metric_path>, (<metric_timestamp>, <metric_value>))
+//
+// The embedded PathParserHelper also allows the parser to handle plaintext lines.
+type pickleParser struct {
+	PathParserHelper
+}
+
+var _ (BatchParser) = (*pickleParser)(nil)
+
+// ParseBatch decodes the pickled list of datapoints. The payload is decoded
+// without executing any of the Python code that a pickle can reference, only
+// lists, tuples, strings and numbers are accepted.
+//
+// The errors never include the decoded values, since values shared through the
+// pickle memo can make their text representation arbitrarily large. They report
+// the index of the datapoint and the Go type of the offending value instead.
+func (pp *pickleParser) ParseBatch(payload []byte) ([]*metricspb.Metric, []error, error) {
+	decoded, err := decodePickle(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid carbon pickle: %v", err)
+	}
+
+	datapoints, ok := decoded.(*pickleList)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid carbon pickle: expected a list, got %T", decoded)
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(datapoints.items))
+	var invalid []error
+	for i, datapoint := range datapoints.items {
+		metric, err := pp.parseDatapoint(datapoint)
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("invalid carbon pickle datapoint %d: %v", i, err))
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, invalid, nil
+}
+
+func (pp *pickleParser) parseDatapoint(datapoint interface{}) (*metricspb.Metric, error) {
+	pathAndPoint, ok := datapoint.(*pickleTuple)
+	if !ok || len(pathAndPoint.items) != 2 {
+		return nil, fmt.Errorf("expected a (path, (timestamp, value)) tuple, got %T", datapoint)
+	}
+	path, ok := pathAndPoint.items[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("path is a %T instead of a string", pathAndPoint.items[0])
+	}
+	timeAndValue, ok := pathAndPoint.items[1].(*pickleTuple)
+	if !ok || len(timeAndValue.items) != 2 {
+		return nil, fmt.Errorf("expected a (timestamp, value) tuple, got %T", pathAndPoint.items[1])
+	}
+
+	parsedPath := ParsedPath{}
+	if err := pp.pathParser.ParsePath(path, &parsedPath); err != nil {
+		return nil, fmt.Errorf("invalid metric path of length %d", len(path))
+	}
+
+	unixTime, err := pickleTimestamp(timeAndValue.items[0])
+	if err != nil {
+		return nil, err
+	}
+
+	point := metricspb.Point{
+		Timestamp: convertUnixSec(unixTime),
+	}
+	switch v := timeAndValue.items[1].(type) {
+	case int64:
+		point.Value = &metricspb.Point_Int64Value{Int64Value: v}
+	case float64:
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: v}
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(v).Float64()
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: f}
+	case string:
+		if intVal, err := strconv.ParseInt(v, 10, 64); err == nil {
+			point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
+		} else if dblVal, err := strconv.ParseFloat(v, 64); err == nil {
+			point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
+		} else {
+			return nil, errors.New("value is a string that is not a number")
+		}
+	default:
+		return nil, fmt.Errorf("value is a %T instead of a number", v)
+	}
+
+	return buildMetricForParsedPath(parsedPath, &point), nil
+}
+
+// pickleTimestamp converts the decoded timestamp of a datapoint to Unix seconds.
+// Relays may send the timestamp as an integer, a float or a string.
+func pickleTimestamp(v interface{}) (int64, error) {
+	switch ts := v.(type) {
+	case int64:
+		return ts, nil
+	case float64:
+		return int64(ts), nil
+	case string:
+		if unixTime, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			return unixTime, nil
+		}
+		f, err := strconv.ParseFloat(ts, 64)
+		if err != nil {
+			return 0, errors.New("timestamp is a string that is not a number")
+		}
+		return int64(f), nil
+	}
+	return 0, fmt.Errorf("timestamp is a %T instead of a number", v)
+}
+
+func pickleDefaultConfig() ParserConfig {
+	return &PickleConfig{}
+}
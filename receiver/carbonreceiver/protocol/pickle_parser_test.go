@@ -0,0 +1,247 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"math"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPickleConfigBuildParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ParserConfig
+		wantErr bool
+	}{
+		{
+			name:    "nil_method_receiver",
+			config:  (*PickleConfig)(nil),
+			wantErr: true,
+		},
+		{
+			name:   "no_rules",
+			config: &PickleConfig{},
+		},
+		{
+			name: "invalid_regexp",
+			config: &PickleConfig{
+				Rules: []*RegexRule{
+					{Regexp: "(?<bad>test)"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid_rules",
+			config: &PickleConfig{
+				Rules: []*RegexRule{
+					{Regexp: "(?P<key_good>test).env(?P<key_env>[^.]*).(?P<key_host>[^.]*)"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.BuildParser()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Implements(t, (*BatchParser)(nil), got)
+		})
+	}
+}
+
+func Test_pickleParser_ParseBatch(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	bp := p.(BatchParser)
+
+	ts := &timestamppb.Timestamp{Seconds: 1600000000}
+	tests := []struct {
+		name        string
+		payload     string
+		want        []*metricspb.Metric
+		wantInvalid int
+		wantErr     bool
+	}{
+		{
+			// [("test.metric;key=value", (1600000000, 42)),
+			//  ("test.double", (1600000000.5, 1.5)),
+			//  ("test.string", ("1600000000", "7"))]
+			name:    "valid_datapoints",
+			payload: "\x80\x02]q\x00(X\x15\x00\x00\x00test.metric;key=valueq\x01J\x00\x10^_K*\x86q\x02\x86q\x03X\x0b\x00\x00\x00test.doubleq\x04GA\xd7\xd7\x84\x00 \x00\x00G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x05\x86q\x06X\x0b\x00\x00\x00test.stringq\x07X\n\x00\x00\x001600000000q\x08X\x01\x00\x00\x007q\x09\x86q\n\x86q\x0be.",
+			want: []*metricspb.Metric{
+				buildMetric(
+					metricspb.MetricDescriptor_GAUGE_INT64,
+					"test.metric",
+					[]string{"key"},
+					[]string{"value"},
+					&metricspb.Point{
+						Timestamp: ts,
+						Value:     &metricspb.Point_Int64Value{Int64Value: 42},
+					},
+				),
+				buildMetric(
+					metricspb.MetricDescriptor_GAUGE_DOUBLE,
+					"test.double",
+					nil,
+					nil,
+					&metricspb.Point{
+						Timestamp: ts,
+						Value:     &metricspb.Point_DoubleValue{DoubleValue: 1.5},
+					},
+				),
+				buildMetric(
+					metricspb.MetricDescriptor_GAUGE_INT64,
+					"test.string",
+					nil,
+					nil,
+					&metricspb.Point{
+						Timestamp: ts,
+						Value:     &metricspb.Point_Int64Value{Int64Value: 7},
+					},
+				),
+			},
+		},
+		{
+			// [("test.big", (1600000000, 2**70))]
+			name:    "big_int_value",
+			payload: "\x80\x02]q\x00X\x08\x00\x00\x00test.bigq\x01J\x00\x10^_\x8a\x09\x00\x00\x00\x00\x00\x00\x00\x00@\x86q\x02\x86q\x03a.",
+			want: []*metricspb.Metric{
+				buildMetric(
+					metricspb.MetricDescriptor_GAUGE_DOUBLE,
+					"test.big",
+					nil,
+					nil,
+					&metricspb.Point{
+						Timestamp: ts,
+						Value:     &metricspb.Point_DoubleValue{DoubleValue: math.Pow(2, 70)},
+					},
+				),
+			},
+		},
+		{
+			// [("a", (1, 2)), "bad", ("b", ("x", 2)), ("c", (1, "y")), (3, (1, 2)), ("d", (1, None))]
+			name:    "invalid_datapoints",
+			payload: "\x80\x02]q\x00(X\x01\x00\x00\x00aq\x01K\x01K\x02\x86q\x02\x86q\x03X\x03\x00\x00\x00badq\x04X\x01\x00\x00\x00bq\x05X\x01\x00\x00\x00xq\x06K\x02\x86q\x07\x86q\x08X\x01\x00\x00\x00cq\x09K\x01X\x01\x00\x00\x00yq\n\x86q\x0b\x86q\x0cK\x03h\x02\x86q\x0dX\x01\x00\x00\x00dq\x0eK\x01N\x86q\x0f\x86q\x10e.",
+			want: []*metricspb.Metric{
+				buildMetric(
+					metricspb.MetricDescriptor_GAUGE_INT64,
+					"a",
+					nil,
+					nil,
+					&metricspb.Point{
+						Timestamp: &timestamppb.Timestamp{Seconds: 1},
+						Value:     &metricspb.Point_Int64Value{Int64Value: 2},
+					},
+				),
+			},
+			wantInvalid: 5,
+		},
+		{
+			// ("not", "a list")
+			name:    "not_a_list",
+			payload: "\x80\x02X\x03\x00\x00\x00notq\x00X\x06\x00\x00\x00a listq\x01\x86q\x02.",
+			wantErr: true,
+		},
+		{
+			// Tuples sharing their items through the memo, doubling the decoded
+			// size at each level.
+			name:    "shared_tuples",
+			payload: string(sharedTuplesPickle(64)),
+			wantErr: true,
+		},
+		{
+			name:    "unsafe_pickle",
+			payload: "\x80\x02]q\x00cposix\nsystem\nq\x01X\x04\x00\x00\x00trueq\x02\x85q\x03Rq\x04a.",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, invalid, err := bp.ParseBatch([]byte(tt.payload))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, invalid, tt.wantInvalid)
+			for _, err := range invalid {
+				assert.Less(t, len(err.Error()), 128, "errors must not include the decoded values")
+			}
+		})
+	}
+}
+
+func Test_pickleParser_ParseBatchErrors(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	// [("a", (1, 2)), "bad", ("b", ("x", 2)), ("c", (1, "y")), (3, (1, 2)), ("d", (1, None))]
+	payload := "\x80\x02]q\x00(X\x01\x00\x00\x00aq\x01K\x01K\x02\x86q\x02\x86q\x03X\x03\x00\x00\x00badq\x04X\x01\x00\x00\x00bq\x05X\x01\x00\x00\x00xq\x06K\x02\x86q\x07\x86q\x08X\x01\x00\x00\x00cq\x09K\x01X\x01\x00\x00\x00yq\n\x86q\x0b\x86q\x0cK\x03h\x02\x86q\x0dX\x01\x00\x00\x00dq\x0eK\x01N\x86q\x0f\x86q\x10e."
+	_, invalid, err := p.(BatchParser).ParseBatch([]byte(payload))
+	require.NoError(t, err)
+
+	var got []string
+	for _, err := range invalid {
+		got = append(got, err.Error())
+	}
+	assert.Equal(t, []string{
+		"invalid carbon pickle datapoint 1: expected a (path, (timestamp, value)) tuple, got string",
+		"invalid carbon pickle datapoint 2: timestamp is a string that is not a number",
+		"invalid carbon pickle datapoint 3: value is a string that is not a number",
+		"invalid carbon pickle datapoint 4: path is a int64 instead of a string",
+		"invalid carbon pickle datapoint 5: value is a <nil> instead of a number",
+	}, got)
+}
+
+func Test_pickleParser_ParseBatchRegex(t *testing.T) {
+	p, err := (&PickleConfig{
+		Rules: []*RegexRule{
+			{
+				Regexp:     `(?P<key_svc>[^.]+)\.(?P<key_host>[^.]+)\.rpc\.count`,
+				NamePrefix: "rpc",
+				MetricType: string(CumulativeMetricType),
+			},
+		},
+	}).BuildParser()
+	require.NoError(t, err)
+
+	// [("svc.host00.rpc.count", (1600000000, 42))]
+	payload := "\x80\x02]q\x00X\x14\x00\x00\x00svc.host00.rpc.countq\x01J\x00\x10^_K*\x86q\x02\x86q\x03a."
+	got, invalid, err := p.(BatchParser).ParseBatch([]byte(payload))
+	require.NoError(t, err)
+	assert.Empty(t, invalid)
+	assert.Equal(t, []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_CUMULATIVE_INT64,
+			"rpc",
+			[]string{"svc", "host"},
+			[]string{"svc", "host00"},
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1600000000},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 42},
+			},
+		),
+	}, got)
+}
@@ -37,6 +37,8 @@ var (
 
 // carbonreceiver implements a component.MetricsReceiver for Carbon plaintext, aka "line", protocol.
 // see https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol.
+// It also supports the pickle protocol when the "pickle" parser is selected,
+// see https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
 type carbonReceiver struct {
 	sync.Mutex
 	logger *zap.Logger
@@ -101,6 +103,15 @@ func New(
 }
 
 func buildTransportServer(config Config, logger *zap.Logger) (transport.Server, error) {
+	if config.Parser.Type == "pickle" {
+		// The pickle protocol is only defined over TCP.
+		switch strings.ToLower(config.Transport) {
+		case "", "tcp":
+			return transport.NewPickleServer(config.Endpoint, config.TCPIdleTimeout)
+		}
+		return nil, fmt.Errorf("unsupported transport %q for the pickle parser of receiver %q", config.Transport, config.Name())
+	}
+
 	switch strings.ToLower(config.Transport) {
 	case "", "tcp":
 		return transport.NewTCPServer(config.Endpoint, config.TCPIdleTimeout)
@@ -155,6 +155,45 @@ func Test_carbonreceiver_New(t *testing.T) {
 			},
 			wantErr: errors.New("invalid idle timeout: -1s"),
 		},
+		{
+			name: "pickle_parser",
+			args: args{
+				config: Config{
+					ReceiverSettings: configmodels.ReceiverSettings{
+						NameVal: "pickle_parser_rcv",
+					},
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "tcp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{},
+					},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+		},
+		{
+			name: "pickle_parser_udp",
+			args: args{
+				config: Config{
+					ReceiverSettings: configmodels.ReceiverSettings{
+						NameVal: "pickle_parser_udp_rcv",
+					},
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "udp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{},
+					},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+			wantErr: errors.New("unsupported transport \"udp\" for the pickle parser of receiver \"pickle_parser_udp_rcv\""),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
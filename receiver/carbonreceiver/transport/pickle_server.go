@@ -0,0 +1,154 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/translator/internaldata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+)
+
+const (
+	// maxPicklePayloadLength is the maximum length of a pickle payload, the same
+	// limit used by Carbon itself. Connections announcing a larger payload are
+	// closed.
+	maxPicklePayloadLength = 1024 * 1024
+)
+
+var (
+	errNotBatchParser = errors.New(
+		"the pickle transport requires a parser that implements protocol.BatchParser")
+)
+
+// pickleServer is a TCP server that receives the length-prefixed pickled
+// payloads of the Carbon pickle protocol, see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+type pickleServer struct {
+	*tcpServer
+}
+
+var _ (Server) = (*pickleServer)(nil)
+
+// NewPickleServer creates a transport.Server that receives the Carbon pickle
+// protocol over TCP.
+func NewPickleServer(
+	addr string,
+	idleTimeout time.Duration,
+) (Server, error) {
+	t, err := newTCPServer(addr, idleTimeout)
+	if err != nil {
+		return nil, err
+	}
+	t.handler = t.handlePickleConnection
+	return &pickleServer{tcpServer: t}, nil
+}
+
+// ListenAndServe requires the parser to be a protocol.BatchParser, since each
+// payload carries several datapoints.
+func (p *pickleServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.MetricsConsumer,
+	reporter Reporter,
+) error {
+	if _, ok := parser.(protocol.BatchParser); parser != nil && !ok {
+		return errNotBatchParser
+	}
+	return p.tcpServer.ListenAndServe(parser, nextConsumer, reporter)
+}
+
+func (t *tcpServer) handlePickleConnection(
+	p protocol.Parser,
+	nextConsumer consumer.MetricsConsumer,
+	conn net.Conn,
+) {
+	defer conn.Close()
+	bp := p.(protocol.BatchParser)
+	header := make([]byte, 4)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"Pickle Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// Each payload is prefixed by its length as a 4 bytes big-endian
+		// unsigned integer. The reads below fail when the connection is closed
+		// (either by client or server) or on idle timeout.
+		if _, err := io.ReadFull(conn, header); err != nil {
+			if err != io.EOF {
+				t.reporter.OnDebugf(
+					"Pickle Transport (%s) - error reading header: %v",
+					t.ln.Addr(),
+					err)
+			}
+			return
+		}
+
+		length := binary.BigEndian.Uint32(header)
+		if length > maxPicklePayloadLength {
+			t.reporter.OnDebugf(
+				"Pickle Transport (%s) - payload length %d exceeds the maximum of %d",
+				t.ln.Addr(),
+				length,
+				maxPicklePayloadLength)
+			return
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			t.reporter.OnDebugf(
+				"Pickle Transport (%s) - error reading payload: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, invalid, err := bp.ParseBatch(payload)
+		if err != nil {
+			// The payload length is known so the next payload can still be read.
+			t.reporter.OnTranslationError(ctx, err)
+			t.reporter.OnMetricsProcessed(ctx, 0, 0, nil)
+			continue
+		}
+		for _, invalidErr := range invalid {
+			t.reporter.OnTranslationError(ctx, invalidErr)
+		}
+
+		if len(metrics) > 0 {
+			md := consumerdata.MetricsData{
+				Metrics: metrics,
+			}
+			err = nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(md))
+		}
+		t.reporter.OnMetricsProcessed(ctx, len(metrics)+len(invalid), len(invalid), err)
+		if err != nil {
+			// As with the plaintext protocol, close the connection to report the
+			// error back to the client.
+			return
+		}
+	}
+}
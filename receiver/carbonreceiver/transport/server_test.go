@@ -15,6 +15,8 @@
 package transport
 
 import (
+	"encoding/binary"
+	"io"
 	"net"
 	"runtime"
 	"strconv"
@@ -113,3 +115,102 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_PickleServer_ListenAndServe(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	svr, err := NewPickleServer(addr, 1*time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, svr)
+
+	mc := new(consumertest.MetricsSink)
+	p, err := (&protocol.PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	mr := NewMockReporter(3)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, svr.ListenAndServe(p, mc, mr))
+	}()
+
+	runtime.Gosched()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	// [("test.metric", (1582230020, 1))] and a pickle that imports os.system.
+	valid := "\x80\x02]q\x00X\x0b\x00\x00\x00test.metricq\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03a."
+	unsafe := "\x80\x02]q\x00cposix\nsystem\nq\x01X\x04\x00\x00\x00trueq\x02\x85q\x03Rq\x04a."
+	for _, payload := range []string{valid, unsafe, valid} {
+		_, err = conn.Write(pickleFrame(payload))
+		require.NoError(t, err)
+	}
+
+	mr.WaitAllOnMetricsProcessedCalls()
+	require.NoError(t, conn.Close())
+
+	require.NoError(t, svr.Close())
+	wgListenAndServe.Wait()
+
+	mdd := mc.AllMetrics()
+	require.Len(t, mdd, 2)
+	for _, md := range mdd {
+		ocmd := internaldata.MetricsToOC(md)
+		require.Len(t, ocmd, 1)
+		require.Len(t, ocmd[0].Metrics, 1)
+		metric := ocmd[0].Metrics[0]
+		assert.Equal(t, "test.metric", metric.GetMetricDescriptor().GetName())
+		assert.Equal(t, int64(1582230020), metric.GetTimeseries()[0].GetPoints()[0].GetTimestamp().GetSeconds())
+	}
+}
+
+func Test_PickleServer_PayloadTooLarge(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	svr, err := NewPickleServer(addr, 1*time.Second)
+	require.NoError(t, err)
+
+	p, err := (&protocol.PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, svr.ListenAndServe(p, new(consumertest.MetricsSink), NewMockReporter(0)))
+	}()
+
+	runtime.Gosched()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	require.NoError(t, err)
+
+	// The server closes the connection without reading any payload.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err)
+
+	require.NoError(t, svr.Close())
+	wgListenAndServe.Wait()
+}
+
+func Test_PickleServer_RequiresBatchParser(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	svr, err := NewPickleServer(addr, 0)
+	require.NoError(t, err)
+	defer svr.Close()
+
+	p, err := (&protocol.PlaintextConfig{}).BuildParser()
+	require.NoError(t, err)
+	assert.Equal(t, errNotBatchParser, svr.ListenAndServe(p, new(consumertest.MetricsSink), NewMockReporter(0)))
+}
+
+// pickleFrame prefixes the pickle payload with its length.
+func pickleFrame(payload string) []byte {
+	frame := make([]byte, 4, 4+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	return append(frame, payload...)
+}
@@ -43,6 +43,10 @@ type tcpServer struct {
 	wg          sync.WaitGroup
 	idleTimeout time.Duration
 	reporter    Reporter
+
+	// handler serves each accepted connection, reading either plaintext lines
+	// or pickle payloads.
+	handler func(p protocol.Parser, nextConsumer consumer.MetricsConsumer, conn net.Conn)
 }
 
 var _ (Server) = (*tcpServer)(nil)
@@ -52,6 +56,15 @@ func NewTCPServer(
 	addr string,
 	idleTimeout time.Duration,
 ) (Server, error) {
+	t, err := newTCPServer(addr, idleTimeout)
+	if err != nil {
+		return nil, err
+	}
+	t.handler = t.handleConnection
+	return t, nil
+}
+
+func newTCPServer(addr string, idleTimeout time.Duration) (*tcpServer, error) {
 	if idleTimeout < 0 {
 		return nil, fmt.Errorf("invalid idle timeout: %v", idleTimeout)
 	}
@@ -94,7 +107,7 @@ func (t *tcpServer) ListenAndServe(
 			connMapMtx.Unlock()
 			t.wg.Add(1)
 			go func(c net.Conn) {
-				t.handleConnection(parser, nextConsumer, c)
+				t.handler(parser, nextConsumer, c)
 				connMapMtx.Lock()
 				delete(acceptedConnMap, c)
 				connMapMtx.Unlock()
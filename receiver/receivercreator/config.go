@@ -30,6 +30,9 @@ const (
 	endpointConfigKey = "endpoint"
 	// configKey is the key name in a subreceiver.
 	configKey = "config"
+	// receiverAnnotationPrefix is the prefix of the pod annotations describing a receiver to start
+	// for the pod. The suffix is the receiver type (ie io.opentelemetry.receiver/redis).
+	receiverAnnotationPrefix = "io.opentelemetry.receiver/"
 )
 
 // receiverConfig describes a receiver instance with a default config.
@@ -41,6 +44,9 @@ type receiverConfig struct {
 	// config is the map configured by the user in the config file. It is the contents of the map from
 	// the "config" section. The keys and values are arbitrarily configured by the user.
 	config userConfigMap
+	// strict rejects config keys that are unknown to the receiver's default config. It is set
+	// for configs that don't come from the config file, like pod annotations.
+	strict bool
 }
 
 // userConfigMap is an arbitrary map of string keys to arbitrary values as specified by the user
@@ -79,6 +85,18 @@ type Config struct {
 	receiverTemplates             map[string]receiverTemplate
 	// WatchObservers are the extensions to listen to endpoints from.
 	WatchObservers []configmodels.Type `mapstructure:"watch_observers"`
+	// PodAnnotations configures receivers started from the annotations of discovered pods.
+	PodAnnotations PodAnnotationsConfig `mapstructure:"pod_annotations"`
+}
+
+// PodAnnotationsConfig configures the receivers that can be started from pod annotations
+// such as io.opentelemetry.receiver/redis: "{endpoint: '`endpoint`:6379'}".
+type PodAnnotationsConfig struct {
+	// Enabled turns on starting receivers from pod annotations. Default is false.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers is the list of receiver types that can be started from pod annotations.
+	// It must not be empty when Enabled is true.
+	AllowedReceivers []configmodels.Type `mapstructure:"allowed_receivers"`
 }
 
 // Copied from the Viper but changed to use the same delimiter.
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
 )
@@ -64,7 +65,7 @@ func exampleCreatorFactory(t *testing.T) (*mockHostFactories, *configmodels.Conf
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	return &mockHostFactories{factories: factories}, cfg
 }
@@ -86,4 +87,20 @@ func TestLoadConfig(t *testing.T) {
 		endpointConfigKey: "localhost:12345",
 	}, r1.receiverTemplates["examplereceiver/1"].config)
 	assert.Equal(t, []configmodels.Type{"mock_observer"}, r1.WatchObservers)
+	assert.False(t, r1.PodAnnotations.Enabled)
+
+	r2 := cfg.Receivers["receiver_creator/2"].(*Config)
+	assert.Empty(t, r2.receiverTemplates)
+	assert.Equal(t, PodAnnotationsConfig{
+		Enabled:          true,
+		AllowedReceivers: []configmodels.Type{"redis", "examplereceiver"},
+	}, r2.PodAnnotations)
+}
+
+func TestLoadConfigNoAllowedReceivers(t *testing.T) {
+	v := config.NewViper()
+	v.Set("pod_annotations", map[string]interface{}{"enabled": true})
+
+	cfg := createDefaultConfig()
+	assert.Equal(t, errNoAllowedReceivers, customUnmarshaler(v, cfg))
 }
@@ -16,7 +16,9 @@ package receivercreator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/collector/component"
@@ -31,13 +33,17 @@ const (
 	typeStr = "receiver_creator"
 )
 
+var errNoAllowedReceivers = errors.New("pod_annotations.allowed_receivers must not be empty when pod_annotations is enabled")
+
 // NewFactory creates a factory for receiver creator.
 func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithCustomUnmarshaler(customUnmarshaler),
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -56,9 +62,68 @@ func createMetricsReceiver(
 	cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	return newReceiverCreator(params.Logger, consumer, cfg.(*Config))
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params, cfg.(*Config))
+	r.RegisterMetricsConsumer(consumer)
+	return r, nil
+}
+
+func createTracesReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.TracesConsumer,
+) (component.TracesReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params, cfg.(*Config))
+	r.RegisterTracesConsumer(consumer)
+	return r, nil
 }
 
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params, cfg.(*Config))
+	r.RegisterLogsConsumer(consumer)
+	return r, nil
+}
+
+// getOrCreateReceiverCreator returns the receiver_creator of the given config, so that the
+// same instance is shared by the pipelines of different data types.
+func getOrCreateReceiverCreator(params component.ReceiverCreateParams, cfg *Config) *receiverCreator {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+	r := receivers[cfg]
+	if r == nil {
+		r = newReceiverCreator(params.Logger, cfg)
+		receivers[cfg] = r
+	}
+	return r
+}
+
+// removeReceiverCreator forgets the receiver_creator of the given config once it's shut down, so
+// that reloading the configuration doesn't keep the previous instances around.
+func removeReceiverCreator(r *receiverCreator) {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+	if receivers[r.cfg] == r {
+		delete(receivers, r.cfg)
+	}
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*receiverCreator{}
+
 func customUnmarshaler(sourceViperSection *viper.Viper, intoCfg interface{}) error {
 	if sourceViperSection == nil {
 		// Nothing to do if there is no config given.
@@ -70,6 +135,10 @@ func customUnmarshaler(sourceViperSection *viper.Viper, intoCfg interface{}) err
 		return err
 	}
 
+	if c.PodAnnotations.Enabled && len(c.PodAnnotations.AllowedReceivers) == 0 {
+		return errNoAllowedReceivers
+	}
+
 	receiversCfg := viperSub(sourceViperSection, receiversConfigKey)
 
 	for subreceiverKey := range receiversCfg.AllSettings() {
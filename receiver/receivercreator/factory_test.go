@@ -19,8 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
 )
 
@@ -38,7 +39,34 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 
 	mReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
-	assert.Error(t, err)
-	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
+	assert.Equal(t, errNilNextConsumer, err)
 	assert.Nil(t, mReceiver)
+
+	trReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, trReceiver)
+
+	lReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, lReceiver)
+}
+
+func TestReceiverCreatorRemovedOnShutdown(t *testing.T) {
+	factory := NewFactory()
+	cfg := createDefaultConfig()
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, &mockMetricsConsumer{})
+	require.NoError(t, err)
+	require.NoError(t, mReceiver.Shutdown(context.Background()))
+
+	receiverLock.Lock()
+	assert.NotContains(t, receivers, cfg.(*Config))
+	receiverLock.Unlock()
+
+	// a new instance is created for the same config, as it would be on a configuration reload
+	tReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	assert.NotSame(t, mReceiver, tReceiver)
+	require.NoError(t, tReceiver.Shutdown(context.Background()))
 }
@@ -19,7 +19,9 @@ import (
 )
 
 var pod = observer.Pod{
-	Name: "pod-1",
+	Name:      "pod-1",
+	Namespace: "default",
+	UID:       "pod-1-UID",
 	Labels: map[string]string{
 		"app":    "redis",
 		"region": "west-1",
@@ -29,6 +31,12 @@ var pod = observer.Pod{
 	},
 }
 
+var podResourceAttributes = map[string]string{
+	"k8s.pod.name":       "pod-1",
+	"k8s.pod.uid":        "pod-1-UID",
+	"k8s.namespace.name": "default",
+}
+
 var podEndpoint = observer.Endpoint{
 	ID:      "pod-1",
 	Target:  "localhost",
@@ -46,6 +54,57 @@ var portEndpoint = observer.Endpoint{
 	},
 }
 
+var containerEndpoint = observer.Endpoint{
+	ID:     "container-1",
+	Target: "172.17.0.2:6379",
+	Details: observer.Container{
+		Name:        "redis",
+		Image:       "redis:6",
+		ContainerID: "3f4b2e8a9c1d",
+		Command:     "redis-server",
+		Labels: map[string]string{
+			"env": "prod",
+		},
+		Port:      6379,
+		HostPort:  16379,
+		Host:      "172.17.0.2",
+		Transport: observer.ProtocolTCP,
+	},
+}
+
+var serviceEndpoint = observer.Endpoint{
+	ID:     "service-1",
+	Target: "10.96.0.10:8080",
+	Details: observer.Service{
+		Name:      "kube-state-metrics",
+		Namespace: "kube-system",
+		Labels: map[string]string{
+			"app": "kube-state-metrics",
+		},
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		ClusterIP: "10.96.0.10",
+		PortName:  "http-metrics",
+		Port:      8080,
+		Transport: observer.ProtocolTCP,
+	},
+}
+
+var nodeEndpoint = observer.Endpoint{
+	ID:     "node-1",
+	Target: "10.0.0.1:10250",
+	Details: observer.Node{
+		Name: "node-1",
+		Labels: map[string]string{
+			"kubernetes.io/os": "linux",
+		},
+		InternalIP:          "10.0.0.1",
+		Hostname:            "node-1",
+		KubeletEndpointPort: 10250,
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
@@ -16,9 +16,12 @@ package receivercreator
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -36,6 +39,9 @@ type observerHandler struct {
 	receiversByEndpointID receiverMap
 	// runner starts and stops receiver instances.
 	runner runner
+	// annotationReceivers is the set of receiver types that can be started from pod
+	// annotations. Empty when starting receivers from pod annotations is disabled.
+	annotationReceivers map[configmodels.Type]bool
 }
 
 // Shutdown all receivers started at runtime.
@@ -80,46 +86,101 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
-			obs.logger.Info("starting receiver",
-				zap.String("name", template.fullName),
-				zap.String("type", string(template.typeStr)),
-				zap.String("endpoint", e.Target),
-				zap.String("endpoint_id", string(e.ID)))
+			obs.startReceiver(e, env, template.receiverConfig)
+		}
 
-			resolvedConfig, err := expandMap(template.config, env)
-			if err != nil {
-				obs.logger.Error("unable to resolve template config", zap.String("receiver", template.fullName), zap.Error(err))
-				continue
+		if pod, ok := e.Details.(observer.Pod); ok && len(obs.annotationReceivers) > 0 {
+			for _, rcvr := range obs.receiversFromAnnotations(pod) {
+				obs.startReceiver(e, env, rcvr)
 			}
+		}
+	}
+}
 
-			discoveredConfig := userConfigMap{}
+// startReceiver starts a receiver for the endpoint after expanding its config.
+func (obs *observerHandler) startReceiver(e observer.Endpoint, env observer.EndpointEnv, receiver receiverConfig) {
+	obs.logger.Info("starting receiver",
+		zap.String("name", receiver.fullName),
+		zap.String("type", string(receiver.typeStr)),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
+
+	resolvedConfig, err := expandMap(receiver.config, env)
+	if err != nil {
+		obs.logger.Error("unable to resolve template config", zap.String("receiver", receiver.fullName), zap.Error(err))
+		return
+	}
 
-			// If user didn't set endpoint set to default value.
-			if _, ok := resolvedConfig[endpointConfigKey]; !ok {
-				discoveredConfig[endpointConfigKey] = e.Target
-			}
+	discoveredConfig := userConfigMap{}
 
-			resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
+	// If user didn't set endpoint set to default value.
+	if _, ok := resolvedConfig[endpointConfigKey]; !ok {
+		discoveredConfig[endpointConfigKey] = e.Target
+	}
 
-			if err != nil {
-				obs.logger.Error("unable to resolve discovered config", zap.String("receiver", template.fullName), zap.Error(err))
-				continue
-			}
+	resolvedDiscoveredConfig, err := expandMap(discoveredConfig, env)
 
-			rcvr, err := obs.runner.start(receiverConfig{
-				fullName: template.fullName,
-				typeStr:  template.typeStr,
-				config:   resolvedConfig,
-			}, resolvedDiscoveredConfig)
+	if err != nil {
+		obs.logger.Error("unable to resolve discovered config", zap.String("receiver", receiver.fullName), zap.Error(err))
+		return
+	}
 
-			if err != nil {
-				obs.logger.Error("failed to start receiver", zap.String("receiver", template.fullName))
-				continue
-			}
+	rcvr, err := obs.runner.start(receiverConfig{
+		fullName: receiver.fullName,
+		typeStr:  receiver.typeStr,
+		config:   resolvedConfig,
+		strict:   receiver.strict,
+	}, resolvedDiscoveredConfig, endpointResourceAttributes(e))
+
+	if err != nil {
+		obs.logger.Error("failed to start receiver", zap.String("receiver", receiver.fullName), zap.Error(err))
+		return
+	}
 
-			obs.receiversByEndpointID.Put(e.ID, rcvr)
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
+}
+
+// receiversFromAnnotations returns the receivers described by the annotations of the pod
+// whose type is allowed. The annotation value is the receiver config in YAML.
+func (obs *observerHandler) receiversFromAnnotations(pod observer.Pod) []receiverConfig {
+	var receivers []receiverConfig
+	for key, value := range pod.Annotations {
+		if !strings.HasPrefix(key, receiverAnnotationPrefix) {
+			continue
+		}
+
+		typeStr := configmodels.Type(strings.TrimPrefix(key, receiverAnnotationPrefix))
+		if !obs.annotationReceivers[typeStr] {
+			obs.logger.Warn("receiver type is not allowed in pod annotations",
+				zap.String("pod", pod.Name), zap.String("annotation", key))
+			continue
+		}
+
+		cfg, err := parseAnnotationConfig(value)
+		if err != nil {
+			obs.logger.Error("unable to parse receiver config from pod annotation",
+				zap.String("pod", pod.Name), zap.String("annotation", key), zap.Error(err))
+			continue
 		}
+
+		receivers = append(receivers, receiverConfig{
+			fullName: string(typeStr),
+			typeStr:  typeStr,
+			config:   cfg,
+			strict:   true,
+		})
+	}
+	return receivers
+}
+
+// parseAnnotationConfig parses the YAML receiver config of an annotation.
+func parseAnnotationConfig(value string) (userConfigMap, error) {
+	v := config.NewViper()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(value)); err != nil {
+		return nil, err
 	}
+	return v.AllSettings(), nil
 }
 
 // OnRemove responds to endpoint removal notifications.
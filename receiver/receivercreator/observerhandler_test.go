@@ -31,8 +31,12 @@ type mockRunner struct {
 	mock.Mock
 }
 
-func (run *mockRunner) start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error) {
-	args := run.Called(receiver, discoveredConfig)
+func (run *mockRunner) start(
+	receiver receiverConfig,
+	discoveredConfig userConfigMap,
+	resourceAttributes map[string]string,
+) (component.Receiver, error) {
+	args := run.Called(receiver, discoveredConfig, resourceAttributes)
 	return args.Get(0).(component.Receiver), args.Error(1)
 }
 
@@ -55,7 +59,7 @@ func TestOnAdd(t *testing.T) {
 		runner:                runner,
 	}
 
-	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, podResourceAttributes).Return(&componenttest.ExampleReceiverProducer{}, nil)
 
 	handler.OnAdd([]observer.Endpoint{
 		portEndpoint,
@@ -102,7 +106,7 @@ func TestOnChange(t *testing.T) {
 	handler.receiversByEndpointID.Put("port-1", oldRcvr)
 
 	runner.On("shutdown", oldRcvr).Return(nil)
-	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}).Return(newRcvr, nil)
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, podResourceAttributes).Return(newRcvr, nil)
 
 	handler.OnChange([]observer.Endpoint{portEndpoint})
 
@@ -129,10 +133,68 @@ func TestDynamicConfig(t *testing.T) {
 		fullName: "name/1",
 		typeStr:  "name",
 		config:   userConfigMap{endpointConfigKey: "localhost:6379"},
-	}, userConfigMap{}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+	}, userConfigMap{}, podResourceAttributes).Return(&componenttest.ExampleReceiverProducer{}, nil)
 	handler.OnAdd([]observer.Endpoint{
 		podEndpoint,
 	})
 
 	runner.AssertExpectations(t)
 }
+
+func TestOnAddPodAnnotations(t *testing.T) {
+	runner := &mockRunner{}
+	handler := &observerHandler{
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+		annotationReceivers:   map[configmodels.Type]bool{"redis": true},
+	}
+	annotatedPod := observer.Endpoint{
+		ID:     "pod-2",
+		Target: "localhost",
+		Details: observer.Pod{
+			Name: "pod-2",
+			Annotations: map[string]string{
+				"io.opentelemetry.receiver/redis": "{endpoint: '`endpoint`:6379', password: secret}",
+				"io.opentelemetry.receiver/nginx": "{endpoint: '`endpoint`:80'}",
+				"prometheus.io/scrape":            "true",
+			},
+		},
+	}
+
+	runner.On("start", receiverConfig{
+		fullName: "redis",
+		typeStr:  "redis",
+		config:   userConfigMap{endpointConfigKey: "localhost:6379", "password": "secret"},
+		strict:   true,
+	}, userConfigMap{}, map[string]string{"k8s.pod.name": "pod-2"}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+
+	handler.OnAdd([]observer.Endpoint{annotatedPod})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+}
+
+func TestOnAddPodAnnotationsDisabled(t *testing.T) {
+	runner := &mockRunner{}
+	handler := &observerHandler{
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+	annotatedPod := observer.Endpoint{
+		ID:     "pod-2",
+		Target: "localhost",
+		Details: observer.Pod{
+			Name: "pod-2",
+			Annotations: map[string]string{
+				"io.opentelemetry.receiver/redis": "{endpoint: '`endpoint`:6379'}",
+			},
+		},
+	}
+
+	handler.OnAdd([]observer.Endpoint{annotatedPod})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+}
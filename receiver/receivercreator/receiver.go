@@ -32,27 +32,42 @@ var (
 )
 
 var _ component.MetricsReceiver = (*receiverCreator)(nil)
+var _ component.TracesReceiver = (*receiverCreator)(nil)
+var _ component.LogsReceiver = (*receiverCreator)(nil)
 
-// receiverCreator implements consumer.MetricsConsumer.
+// receiverCreator starts and stops receivers at runtime. The same instance is used for all the
+// pipelines the receiver_creator is part of, receivers started at runtime being created for each
+// of their data types.
 type receiverCreator struct {
-	nextConsumer    consumer.MetricsConsumer
-	logger          *zap.Logger
-	cfg             *Config
-	observerHandler observerHandler
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TracesConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	logger              *zap.Logger
+	cfg                 *Config
+	observerHandler     observerHandler
 }
 
 // newReceiverCreator creates the receiver_creator with the given parameters.
-func newReceiverCreator(logger *zap.Logger, nextConsumer consumer.MetricsConsumer, cfg *Config) (component.MetricsReceiver, error) {
-	if nextConsumer == nil {
-		return nil, errNilNextConsumer
+func newReceiverCreator(logger *zap.Logger, cfg *Config) *receiverCreator {
+	return &receiverCreator{
+		logger: logger,
+		cfg:    cfg,
 	}
+}
 
-	r := &receiverCreator{
-		logger:       logger,
-		nextConsumer: nextConsumer,
-		cfg:          cfg,
-	}
-	return r, nil
+// RegisterMetricsConsumer sets the consumer of the metrics of the receivers started at runtime.
+func (rc *receiverCreator) RegisterMetricsConsumer(mc consumer.MetricsConsumer) {
+	rc.nextMetricsConsumer = mc
+}
+
+// RegisterTracesConsumer sets the consumer of the traces of the receivers started at runtime.
+func (rc *receiverCreator) RegisterTracesConsumer(tc consumer.TracesConsumer) {
+	rc.nextTracesConsumer = tc
+}
+
+// RegisterLogsConsumer sets the consumer of the logs of the receivers started at runtime.
+func (rc *receiverCreator) RegisterLogsConsumer(lc consumer.LogsConsumer) {
+	rc.nextLogsConsumer = lc
 }
 
 // loggingHost provides a safer version of host that logs errors instead of exiting the process.
@@ -75,15 +90,24 @@ func (rc *receiverCreator) Start(ctx context.Context, host component.Host) error
 		receiverTemplates:     rc.cfg.receiverTemplates,
 		receiversByEndpointID: receiverMap{},
 		runner: &receiverRunner{
-			logger:       rc.logger,
-			nextConsumer: rc.nextConsumer,
-			idNamespace:  rc.cfg.Name(),
+			logger:              rc.logger,
+			nextMetricsConsumer: rc.nextMetricsConsumer,
+			nextTracesConsumer:  rc.nextTracesConsumer,
+			nextLogsConsumer:    rc.nextLogsConsumer,
+			idNamespace:         rc.cfg.Name(),
 			// TODO: not really sure what context should be used here for starting subreceivers
 			// as don't think it makes sense to use Start context as the lifetimes are different.
 			ctx:  context.Background(),
 			host: &loggingHost{host, rc.logger},
 		}}
 
+	if rc.cfg.PodAnnotations.Enabled {
+		rc.observerHandler.annotationReceivers = map[configmodels.Type]bool{}
+		for _, typeStr := range rc.cfg.PodAnnotations.AllowedReceivers {
+			rc.observerHandler.annotationReceivers[typeStr] = true
+		}
+	}
+
 	observers := map[configmodels.Type]observer.Observable{}
 
 	// Match all configured observers to the extensions that are running.
@@ -123,5 +147,6 @@ func (rc *receiverCreator) Start(ctx context.Context, host component.Host) error
 
 // Shutdown stops the receiver_creator and all its receivers started at runtime.
 func (rc *receiverCreator) Shutdown(ctx context.Context) error {
+	removeReceiverCreator(rc)
 	return rc.observerHandler.Shutdown()
 }
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	k8sNodeNameKey    = "k8s.node.name"
+	k8sServiceNameKey = "k8s.service.name"
+)
+
+// endpointResourceAttributes returns the resource attributes identifying the endpoint
+// a receiver was started for.
+func endpointResourceAttributes(e observer.Endpoint) map[string]string {
+	attrs := map[string]string{}
+	switch d := e.Details.(type) {
+	case observer.Pod:
+		addPodAttributes(attrs, d)
+	case observer.Port:
+		addPodAttributes(attrs, d.Pod)
+	case observer.Container:
+		addIfNotEmpty(attrs, conventions.AttributeContainerName, d.Name)
+		addIfNotEmpty(attrs, conventions.AttributeContainerID, d.ContainerID)
+		addIfNotEmpty(attrs, conventions.AttributeContainerImage, d.Image)
+	case observer.Service:
+		addIfNotEmpty(attrs, k8sServiceNameKey, d.Name)
+		addIfNotEmpty(attrs, conventions.AttributeK8sNamespace, d.Namespace)
+	case observer.Node:
+		addIfNotEmpty(attrs, k8sNodeNameKey, d.Name)
+	}
+	return attrs
+}
+
+func addPodAttributes(attrs map[string]string, pod observer.Pod) {
+	addIfNotEmpty(attrs, conventions.AttributeK8sPod, pod.Name)
+	addIfNotEmpty(attrs, conventions.AttributeK8sPodUID, pod.UID)
+	addIfNotEmpty(attrs, conventions.AttributeK8sNamespace, pod.Namespace)
+}
+
+func addIfNotEmpty(attrs map[string]string, key, value string) {
+	if value != "" {
+		attrs[key] = value
+	}
+}
+
+// addResourceAttributes inserts the attributes into the resource, keeping
+// the values already set by the receiver.
+func addResourceAttributes(resource pdata.Resource, attrs map[string]string) {
+	if resource.IsNil() {
+		resource.InitEmpty()
+	}
+	resourceAttrs := resource.Attributes()
+	for k, v := range attrs {
+		resourceAttrs.InsertString(k, v)
+	}
+}
+
+// resourceMetricsConsumer adds resource attributes to metrics before passing them
+// to the next consumer.
+type resourceMetricsConsumer struct {
+	next  consumer.MetricsConsumer
+	attrs map[string]string
+}
+
+// newResourceMetricsConsumer returns next if there are no attributes to add.
+func newResourceMetricsConsumer(next consumer.MetricsConsumer, attrs map[string]string) consumer.MetricsConsumer {
+	if len(attrs) == 0 {
+		return next
+	}
+	return &resourceMetricsConsumer{next: next, attrs: attrs}
+}
+
+func (c *resourceMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		addResourceAttributes(rm.Resource(), c.attrs)
+	}
+	return c.next.ConsumeMetrics(ctx, md)
+}
+
+// resourceTracesConsumer adds resource attributes to traces before passing them
+// to the next consumer.
+type resourceTracesConsumer struct {
+	next  consumer.TracesConsumer
+	attrs map[string]string
+}
+
+// newResourceTracesConsumer returns next if there are no attributes to add.
+func newResourceTracesConsumer(next consumer.TracesConsumer, attrs map[string]string) consumer.TracesConsumer {
+	if len(attrs) == 0 {
+		return next
+	}
+	return &resourceTracesConsumer{next: next, attrs: attrs}
+}
+
+func (c *resourceTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		addResourceAttributes(rs.Resource(), c.attrs)
+	}
+	return c.next.ConsumeTraces(ctx, td)
+}
+
+// resourceLogsConsumer adds resource attributes to logs before passing them
+// to the next consumer.
+type resourceLogsConsumer struct {
+	next  consumer.LogsConsumer
+	attrs map[string]string
+}
+
+// newResourceLogsConsumer returns next if there are no attributes to add.
+func newResourceLogsConsumer(next consumer.LogsConsumer, attrs map[string]string) consumer.LogsConsumer {
+	if len(attrs) == 0 {
+		return next
+	}
+	return &resourceLogsConsumer{next: next, attrs: attrs}
+}
+
+func (c *resourceLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		addResourceAttributes(rl.Resource(), c.attrs)
+	}
+	return c.next.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestEndpointResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint observer.Endpoint
+		want     map[string]string
+	}{
+		{"pod", podEndpoint, podResourceAttributes},
+		{"port", portEndpoint, podResourceAttributes},
+		{"container", containerEndpoint, map[string]string{
+			"container.name":       "redis",
+			"container.id":         "3f4b2e8a9c1d",
+			"container.image.name": "redis:6",
+		}},
+		{"service", serviceEndpoint, map[string]string{
+			"k8s.service.name":   "kube-state-metrics",
+			"k8s.namespace.name": "kube-system",
+		}},
+		{"node", nodeEndpoint, map[string]string{
+			"k8s.node.name": "node-1",
+		}},
+		{"unsupported", unsupportedEndpoint, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointResourceAttributes(tt.endpoint))
+		})
+	}
+}
+
+func TestResourceMetricsConsumer(t *testing.T) {
+	// prepare
+	sink := &consumertest.MetricsSink{}
+	next := newResourceMetricsConsumer(sink, podResourceAttributes)
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(2)
+	md.ResourceMetrics().At(0).Resource().InitEmpty()
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertString("k8s.pod.name", "set-by-receiver")
+
+	// test
+	require.NoError(t, next.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	require.Len(t, sink.AllMetrics(), 1)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	assertResourceAttributes(t, rms.At(0).Resource(), "set-by-receiver")
+	assertResourceAttributes(t, rms.At(1).Resource(), "pod-1")
+}
+
+func TestResourceTracesConsumer(t *testing.T) {
+	// prepare
+	sink := &consumertest.TracesSink{}
+	next := newResourceTracesConsumer(sink, podResourceAttributes)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+
+	// test
+	require.NoError(t, next.ConsumeTraces(context.Background(), td))
+
+	// verify
+	require.Len(t, sink.AllTraces(), 1)
+	assertResourceAttributes(t, sink.AllTraces()[0].ResourceSpans().At(0).Resource(), "pod-1")
+}
+
+func TestResourceLogsConsumer(t *testing.T) {
+	// prepare
+	sink := &consumertest.LogsSink{}
+	next := newResourceLogsConsumer(sink, podResourceAttributes)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+
+	// test
+	require.NoError(t, next.ConsumeLogs(context.Background(), ld))
+
+	// verify
+	require.Len(t, sink.AllLogs(), 1)
+	assertResourceAttributes(t, sink.AllLogs()[0].ResourceLogs().At(0).Resource(), "pod-1")
+}
+
+func TestResourceConsumersWithoutAttributes(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	tracesSink := &consumertest.TracesSink{}
+	logsSink := &consumertest.LogsSink{}
+
+	assert.Same(t, metricsSink, newResourceMetricsConsumer(metricsSink, map[string]string{}))
+	assert.Same(t, tracesSink, newResourceTracesConsumer(tracesSink, nil))
+	assert.Same(t, logsSink, newResourceLogsConsumer(logsSink, nil))
+}
+
+func assertResourceAttributes(t *testing.T, resource pdata.Resource, podName string) {
+	require.False(t, resource.IsNil())
+	attrs := resource.Attributes()
+	assert.Equal(t, 3, attrs.Len())
+
+	v, ok := attrs.Get("k8s.pod.name")
+	assert.True(t, ok)
+	assert.Equal(t, podName, v.StringVal())
+	v, ok = attrs.Get("k8s.pod.uid")
+	assert.True(t, ok)
+	assert.Equal(t, "pod-1-UID", v.StringVal())
+	v, ok = attrs.Get("k8s.namespace.name")
+	assert.True(t, ok)
+	assert.Equal(t, "default", v.StringVal())
+}
@@ -47,6 +47,9 @@ func Test_ruleEval(t *testing.T) {
 		{"basic port", args{`type.port && name == "http" && pod.labels["app"] == "redis"`, portEndpoint}, true, false},
 		{"basic pod", args{`type.pod && labels["region"] == "west-1"`, podEndpoint}, true, false},
 		{"annotations", args{`type.pod && annotations["scrape"] == "true"`, podEndpoint}, true, false},
+		{"basic service", args{`type.service && annotations["prometheus.io/scrape"] == "true" && port_name == "http-metrics"`, serviceEndpoint}, true, false},
+		{"basic node", args{`type.node && labels["kubernetes.io/os"] == "linux" && kubelet_endpoint_port == 10250`, nodeEndpoint}, true, false},
+		{"basic container", args{`type.container && image == "redis:6" && labels["env"] == "prod" && port == 6379`, containerEndpoint}, true, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -82,6 +85,7 @@ func Test_newRule(t *testing.T) {
 		{"does not start with type", args{"port == 1234"}, true},
 		{"invalid syntax", args{"port =="}, true},
 		{"valid", args{`type.port && port_name == "http"`}, false},
+		{"valid container", args{`type.container && port == 6379`}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
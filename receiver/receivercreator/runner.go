@@ -18,8 +18,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mitchellh/mapstructure"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
@@ -27,25 +30,32 @@ import (
 
 // runner starts and stops receiver instances.
 type runner interface {
-	// start a receiver instance from its static config and discovered config.
-	start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error)
+	// start a receiver instance from its static config and discovered config. The resource
+	// attributes are added to all the data produced by the receiver.
+	start(receiver receiverConfig, discoveredConfig userConfigMap, resourceAttributes map[string]string) (component.Receiver, error)
 	// shutdown a receiver.
 	shutdown(rcvr component.Receiver) error
 }
 
 // receiverRunner handles starting/stopping of a concrete subreceiver instance.
 type receiverRunner struct {
-	logger       *zap.Logger
-	nextConsumer consumer.MetricsConsumer
-	idNamespace  string
-	ctx          context.Context
-	host         component.Host
+	logger              *zap.Logger
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TracesConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	idNamespace         string
+	ctx                 context.Context
+	host                component.Host
 }
 
 var _ runner = (*receiverRunner)(nil)
 
 // start a receiver instance from its static config and discovered config.
-func (run *receiverRunner) start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error) {
+func (run *receiverRunner) start(
+	receiver receiverConfig,
+	discoveredConfig userConfigMap,
+	resourceAttributes map[string]string,
+) (component.Receiver, error) {
 	factory := run.host.GetFactory(component.KindReceiver, receiver.typeStr)
 
 	if factory == nil {
@@ -58,7 +68,7 @@ func (run *receiverRunner) start(receiver receiverConfig, discoveredConfig userC
 	if err != nil {
 		return nil, err
 	}
-	recvr, err := run.createRuntimeReceiver(receiverFactory, cfg)
+	recvr, err := run.createRuntimeReceiver(receiverFactory, cfg, resourceAttributes)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +92,12 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	receiver receiverConfig,
 	discoveredConfig userConfigMap,
 ) (configmodels.Receiver, error) {
+	if receiver.strict {
+		if err := validateConfigKeys(factory, receiver.config); err != nil {
+			return nil, err
+		}
+	}
+
 	mergedConfig := config.NewViper()
 
 	// Merge in the config values specified in the config file.
@@ -104,8 +120,113 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	return receiverConfig, nil
 }
 
-// createRuntimeReceiver creates a receiver that is discovered at runtime.
-func (run *receiverRunner) createRuntimeReceiver(factory component.ReceiverFactory, cfg configmodels.Receiver) (component.MetricsReceiver, error) {
+// validateConfigKeys returns an error if cfg contains keys, or values, that can't be
+// decoded into the default config of the receiver.
+func validateConfigKeys(factory component.ReceiverFactory, cfg userConfigMap) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           factory.CreateDefaultConfig(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(map[string]interface{}(cfg)); err != nil {
+		return fmt.Errorf("invalid config for receiver %q: %v", factory.Type(), err)
+	}
+	return nil
+}
+
+// createRuntimeReceiver creates a receiver that is discovered at runtime for each of the data
+// types of the pipelines receiver_creator is part of and that the receiver supports.
+func (run *receiverRunner) createRuntimeReceiver(
+	factory component.ReceiverFactory,
+	cfg configmodels.Receiver,
+	resourceAttributes map[string]string,
+) (component.Receiver, error) {
 	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
-	return factory.CreateMetricsReceiver(context.Background(), params, cfg, run.nextConsumer)
+	var receivers receiverGroup
+
+	if run.nextMetricsConsumer != nil {
+		rcvr, err := factory.CreateMetricsReceiver(
+			context.Background(), params, cfg, newResourceMetricsConsumer(run.nextMetricsConsumer, resourceAttributes))
+		if err != nil && err != configerror.ErrDataTypeIsNotSupported {
+			return nil, err
+		}
+		receivers = receivers.add(rcvr)
+	}
+
+	if run.nextTracesConsumer != nil {
+		rcvr, err := factory.CreateTracesReceiver(
+			context.Background(), params, cfg, newResourceTracesConsumer(run.nextTracesConsumer, resourceAttributes))
+		if err != nil && err != configerror.ErrDataTypeIsNotSupported {
+			return nil, err
+		}
+		receivers = receivers.add(rcvr)
+	}
+
+	if run.nextLogsConsumer != nil {
+		rcvr, err := factory.CreateLogsReceiver(
+			context.Background(), params, cfg, newResourceLogsConsumer(run.nextLogsConsumer, resourceAttributes))
+		if err != nil && err != configerror.ErrDataTypeIsNotSupported {
+			return nil, err
+		}
+		receivers = receivers.add(rcvr)
+	}
+
+	switch len(receivers) {
+	case 0:
+		return nil, fmt.Errorf("receiver %q does not support any of the data types of the receiver_creator pipelines", factory.Type())
+	case 1:
+		return receivers[0], nil
+	default:
+		return receivers, nil
+	}
+}
+
+// receiverGroup is a receiver made of the distinct receivers a factory created for each data type.
+type receiverGroup []component.Receiver
+
+var _ component.Receiver = (receiverGroup)(nil)
+
+// add appends rcvr unless it is nil or already part of the group.
+func (g receiverGroup) add(rcvr component.Receiver) receiverGroup {
+	if rcvr == nil {
+		return g
+	}
+	for _, r := range g {
+		if r == rcvr {
+			return g
+		}
+	}
+	return append(g, rcvr)
+}
+
+// Start starts all the receivers of the group. If one of them fails to start, the
+// receivers started so far are shut down.
+func (g receiverGroup) Start(ctx context.Context, host component.Host) error {
+	for i, r := range g {
+		if err := r.Start(ctx, host); err != nil {
+			if shutdownErr := g[:i].Shutdown(ctx); shutdownErr != nil {
+				return componenterror.CombineErrors([]error{err, shutdownErr})
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown stops all the receivers of the group.
+func (g receiverGroup) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, r := range g {
+		if err := r.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
 }
@@ -15,16 +15,19 @@
 package receivercreator
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 )
 
 func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
-	run := &receiverRunner{logger: zap.NewNop(), nextConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
+	run := &receiverRunner{logger: zap.NewNop(), nextMetricsConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
 	exampleFactory := &componenttest.ExampleReceiverFactory{}
 	template, err := newReceiverTemplate("examplereceiver/1", nil)
 	require.NoError(t, err)
@@ -41,10 +44,97 @@ func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
 
 	// Test that metric receiver can be created from loaded config.
 	t.Run("test create receiver from loaded config", func(t *testing.T) {
-		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig)
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, nil)
 		require.NoError(t, err)
 		assert.NotNil(t, recvr)
 		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
-		assert.Equal(t, run.nextConsumer, exampleReceiver.MetricsConsumer)
+		assert.Equal(t, run.nextMetricsConsumer, exampleReceiver.MetricsConsumer)
 	})
+
+	t.Run("test create receiver with resource attributes", func(t *testing.T) {
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, podResourceAttributes)
+		require.NoError(t, err)
+		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+		assert.Equal(t, &resourceMetricsConsumer{
+			next:  run.nextMetricsConsumer,
+			attrs: podResourceAttributes,
+		}, exampleReceiver.MetricsConsumer)
+	})
+}
+
+func Test_loadRuntimeReceiverConfigStrict(t *testing.T) {
+	run := &receiverRunner{logger: zap.NewNop(), nextMetricsConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
+	exampleFactory := &componenttest.ExampleReceiverFactory{}
+
+	loadedConfig, err := run.loadRuntimeReceiverConfig(exampleFactory, receiverConfig{
+		fullName: "examplereceiver",
+		typeStr:  "examplereceiver",
+		config:   userConfigMap{endpointConfigKey: "localhost:12345", "extra": "value"},
+		strict:   true,
+	}, userConfigMap{})
+	require.NoError(t, err)
+	assert.Equal(t, "value", loadedConfig.(*componenttest.ExampleReceiver).ExtraSetting)
+
+	_, err = run.loadRuntimeReceiverConfig(exampleFactory, receiverConfig{
+		fullName: "examplereceiver",
+		typeStr:  "examplereceiver",
+		config:   userConfigMap{endpointConfigKey: "localhost:12345", "unknown": "value"},
+		strict:   true,
+	}, userConfigMap{})
+	assert.Error(t, err)
+}
+
+func Test_createRuntimeReceiverWithoutConsumers(t *testing.T) {
+	run := &receiverRunner{logger: zap.NewNop(), idNamespace: "receiver_creator/1"}
+	exampleFactory := &componenttest.ExampleReceiverFactory{}
+
+	_, err := run.createRuntimeReceiver(exampleFactory, exampleFactory.CreateDefaultConfig(), nil)
+	assert.Error(t, err)
+}
+
+func TestReceiverGroup(t *testing.T) {
+	r1 := &componenttest.ExampleReceiverProducer{}
+	r2 := &componenttest.ExampleReceiverProducer{}
+
+	var group receiverGroup
+	group = group.add(r1).add(nil).add(r2).add(r1)
+	require.Len(t, group, 2)
+
+	require.NoError(t, group.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, r1.Started)
+	assert.True(t, r2.Started)
+
+	require.NoError(t, group.Shutdown(context.Background()))
+	assert.True(t, r1.Stopped)
+	assert.True(t, r2.Stopped)
+}
+
+func TestReceiverGroupStartFailure(t *testing.T) {
+	r1 := &componenttest.ExampleReceiverProducer{}
+	r3 := &componenttest.ExampleReceiverProducer{}
+	startErr := errors.New("failed to start")
+
+	group := receiverGroup{r1, &failingReceiver{err: startErr}, r3}
+
+	err := group.Start(context.Background(), componenttest.NewNopHost())
+	assert.Equal(t, startErr, err)
+	// the receivers started before the failure are shut down, the others are never started
+	assert.True(t, r1.Started)
+	assert.True(t, r1.Stopped)
+	assert.False(t, r3.Started)
+}
+
+// failingReceiver is a receiver that fails to start.
+type failingReceiver struct {
+	err error
+}
+
+var _ component.Receiver = (*failingReceiver)(nil)
+
+func (f *failingReceiver) Start(context.Context, component.Host) error {
+	return f.err
+}
+
+func (f *failingReceiver) Shutdown(context.Context) error {
+	return nil
 }
@@ -15,12 +15,44 @@
 package statsdreceiver
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 // Config defines configuration for StatsD receiver.
 type Config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
 	NetAddr                       confignet.NetAddr `mapstructure:",squash"`
+
+	// AggregationInterval is the interval at which the aggregated metrics are
+	// flushed to the next consumer.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// IdleTimeout is the duration after which idle TCP connections are closed.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// MaxLineLength is the maximum length in bytes of the lines received over
+	// TCP. Connections sending longer lines are closed.
+	MaxLineLength int `mapstructure:"max_line_length"`
+
+	// TimerHistogramMapping selects how the observations of timers, histograms
+	// and distributions are reported.
+	TimerHistogramMapping []TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+
+	// HistogramBuckets are the explicit bucket boundaries of the reported
+	// histograms, in increasing order.
+	HistogramBuckets []float64 `mapstructure:"histogram_buckets"`
+}
+
+// TimerHistogramMapping selects how the observations of a StatsD type are reported.
+type TimerHistogramMapping struct {
+	// StatsdType is one of "timer", "histogram" or "distribution".
+	StatsdType string `mapstructure:"statsd_type"`
+
+	// ObserverType is one of "summary" or "histogram".
+	ObserverType protocol.ObserverType `mapstructure:"observer_type"`
 }
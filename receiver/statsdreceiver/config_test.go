@@ -17,6 +17,7 @@ package statsdreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -54,5 +57,13 @@ func TestLoadConfig(t *testing.T) {
 			Endpoint:  "localhost:12345",
 			Transport: "custom_transport",
 		},
+		AggregationInterval: 70 * time.Second,
+		IdleTimeout:         30 * time.Second,
+		MaxLineLength:       1024,
+		TimerHistogramMapping: []TimerHistogramMapping{
+			{StatsdType: "timer", ObserverType: protocol.HistogramObserver},
+			{StatsdType: "distribution", ObserverType: protocol.SummaryObserver},
+		},
+		HistogramBuckets: []float64{1, 10, 100},
 	}, r1)
 }
@@ -13,6 +13,8 @@
 // limitations under the License.
 
 // Package statsdreceiver implements a collector receiver that listens
-// on UDP port 8125 by default for incoming StatsD messages and parses
-// them into OTLP equivalent metric representations.
+// on UDP port 8125 by default, or on TCP or a unix datagram socket, for
+// incoming StatsD messages and parses them into OTLP equivalent metric
+// representations. DogStatsD events and service checks are converted into
+// log records.
 package statsdreceiver
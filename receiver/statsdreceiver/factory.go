@@ -16,8 +16,11 @@ package statsdreceiver
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
@@ -26,9 +29,12 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr             = "statsd"
-	defaultBindEndpoint = "localhost:8125"
-	defaultTransport    = "udp"
+	typeStr                    = "statsd"
+	defaultBindEndpoint        = "localhost:8125"
+	defaultTransport           = "udp"
+	defaultAggregationInterval = 60 * time.Second
+	defaultIdleTimeout         = 5 * time.Minute
+	defaultMaxLineLength       = 64 * 1024
 )
 
 // NewFactory creates a factory for the StatsD receiver.
@@ -37,6 +43,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver),
 	)
 }
 
@@ -50,6 +57,9 @@ func createDefaultConfig() configmodels.Receiver {
 			Endpoint:  defaultBindEndpoint,
 			Transport: defaultTransport,
 		},
+		AggregationInterval: defaultAggregationInterval,
+		IdleTimeout:         defaultIdleTimeout,
+		MaxLineLength:       defaultMaxLineLength,
 	}
 }
 
@@ -59,6 +69,54 @@ func createMetricsReceiver(
 	cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	c := cfg.(*Config)
-	return New(params.Logger, *c, consumer)
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r, err := getReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(consumer)
+	return r, nil
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r, err := getReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterLogsConsumer(consumer)
+	return r, nil
+}
+
+// getReceiver returns the receiver of the configuration, creating it on first use so
+// that the metrics and logs pipelines share a single listener.
+func getReceiver(params component.ReceiverCreateParams, cfg configmodels.Receiver) (*statsdReceiver, error) {
+	rCfg := cfg.(*Config)
+
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	r := receivers[rCfg]
+	if r == nil {
+		var err error
+		if r, err = newReceiver(params.Logger, *rCfg); err != nil {
+			return nil, err
+		}
+		receivers[rCfg] = r
+	}
+	return r, nil
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*statsdReceiver{}
@@ -41,3 +41,27 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lReceiver, "receiver creation failed")
+
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.NoError(t, err)
+	assert.Same(t, lReceiver, mReceiver, "metrics and logs pipelines must share the receiver")
+}
+
+func TestCreateLogsReceiverNilConsumer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, nil)
+	assert.Error(t, err)
+	assert.Nil(t, lReceiver)
+}
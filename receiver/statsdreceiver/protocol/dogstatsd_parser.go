@@ -0,0 +1,230 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	// Attributes of the log records converted from DogStatsD events.
+	eventTitleAttribute          = "event.title"
+	eventTextAttribute           = "event.text"
+	eventPriorityAttribute       = "event.priority"
+	eventAlertTypeAttribute      = "event.alert_type"
+	eventAggregationKeyAttribute = "event.aggregation_key"
+	eventSourceTypeAttribute     = "event.source_type_name"
+
+	// Attributes of the log records converted from DogStatsD service checks.
+	serviceCheckNameAttribute    = "service_check.name"
+	serviceCheckStatusAttribute  = "service_check.status"
+	serviceCheckMessageAttribute = "service_check.message"
+)
+
+// isDogStatsDEvent returns whether the line is a DogStatsD event or service check
+// rather than a metric.
+func isDogStatsDEvent(line string) bool {
+	return strings.HasPrefix(line, eventPrefix) || strings.HasPrefix(line, serviceCheckPrefix)
+}
+
+// parseDogStatsDEvent converts a DogStatsD event or service check into a log record.
+func parseDogStatsDEvent(line string, now int64) (pdata.LogRecord, error) {
+	if strings.HasPrefix(line, eventPrefix) {
+		return parseEvent(line, now)
+	}
+	return parseServiceCheck(line, now)
+}
+
+// parseEvent converts a DogStatsD event of the form
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|#<tags>
+// into a log record.
+func parseEvent(line string, now int64) (pdata.LogRecord, error) {
+	lr := pdata.NewLogRecord()
+
+	headerEnd := strings.Index(line, "}:")
+	if headerEnd < 0 {
+		return lr, fmt.Errorf("invalid event format: %s", line)
+	}
+	lengths := strings.Split(line[len(eventPrefix):headerEnd], ",")
+	if len(lengths) != 2 {
+		return lr, fmt.Errorf("invalid event lengths: %s", line[:headerEnd+1])
+	}
+	titleLength, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLength <= 0 {
+		return lr, fmt.Errorf("invalid event title length: %s", lengths[0])
+	}
+	textLength, err := strconv.Atoi(lengths[1])
+	if err != nil || textLength < 0 {
+		return lr, fmt.Errorf("invalid event text length: %s", lengths[1])
+	}
+
+	rest := line[headerEnd+2:]
+	if len(rest) < titleLength+1+textLength || rest[titleLength] != '|' {
+		return lr, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLength]
+	text := unescapeNewlines(rest[titleLength+1 : titleLength+1+textLength])
+	rest = rest[titleLength+1+textLength:]
+	if rest != "" && rest[0] != '|' {
+		return lr, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	lr.InitEmpty()
+	lr.SetName(title)
+	lr.Body().InitEmpty()
+	lr.Body().SetStringVal(text)
+	lr.SetSeverityText("Info")
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+
+	timestamp := now
+	attrs := lr.Attributes()
+	attrs.InsertString(eventTitleAttribute, title)
+	attrs.InsertString(eventTextAttribute, text)
+	priority := "normal"
+	alertType := "info"
+	var tags []string
+	for _, part := range strings.Split(rest, "|")[1:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if timestamp, err = strconv.ParseInt(part[2:], 10, 64); err != nil {
+				return lr, fmt.Errorf("parse event timestamp: %s", part[2:])
+			}
+		case strings.HasPrefix(part, "h:"):
+			attrs.InsertString(conventions.AttributeHostHostname, part[2:])
+		case strings.HasPrefix(part, "p:"):
+			priority = part[2:]
+		case strings.HasPrefix(part, "t:"):
+			alertType = part[2:]
+		case strings.HasPrefix(part, "k:"):
+			attrs.InsertString(eventAggregationKeyAttribute, part[2:])
+		case strings.HasPrefix(part, "s:"):
+			attrs.InsertString(eventSourceTypeAttribute, part[2:])
+		case strings.HasPrefix(part, "#"):
+			tags = strings.Split(part[1:], ",")
+		default:
+			return lr, fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	switch alertType {
+	case "info", "success":
+	case "warning":
+		lr.SetSeverityText("Warn")
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	case "error":
+		lr.SetSeverityText("Error")
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	default:
+		return lr, fmt.Errorf("unsupported event alert type: %s", alertType)
+	}
+
+	attrs.InsertString(eventPriorityAttribute, priority)
+	attrs.InsertString(eventAlertTypeAttribute, alertType)
+	insertTags(attrs, tags)
+	lr.SetTimestamp(pdata.TimestampUnixNano(timestamp * 1e9))
+	return lr, nil
+}
+
+// parseServiceCheck converts a DogStatsD service check of the form
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+// into a log record.
+func parseServiceCheck(line string, now int64) (pdata.LogRecord, error) {
+	lr := pdata.NewLogRecord()
+
+	parts := strings.Split(line, "|")
+	if len(parts) < 3 || parts[1] == "" {
+		return lr, fmt.Errorf("invalid service check format: %s", line)
+	}
+	name := parts[1]
+	status, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || status < 0 || status > 3 {
+		return lr, fmt.Errorf("invalid service check status: %s", parts[2])
+	}
+
+	lr.InitEmpty()
+	lr.SetName(name)
+	switch status {
+	case 0:
+		lr.SetSeverityText("Info")
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	case 1:
+		lr.SetSeverityText("Warn")
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	case 2:
+		lr.SetSeverityText("Error")
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	}
+
+	timestamp := now
+	attrs := lr.Attributes()
+	attrs.InsertString(serviceCheckNameAttribute, name)
+	attrs.InsertInt(serviceCheckStatusAttribute, status)
+	var tags []string
+	for i := 3; i < len(parts); i++ {
+		part := parts[i]
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if timestamp, err = strconv.ParseInt(part[2:], 10, 64); err != nil {
+				return lr, fmt.Errorf("parse service check timestamp: %s", part[2:])
+			}
+		case strings.HasPrefix(part, "h:"):
+			attrs.InsertString(conventions.AttributeHostHostname, part[2:])
+		case strings.HasPrefix(part, "#"):
+			tags = strings.Split(part[1:], ",")
+		case strings.HasPrefix(part, "m:"):
+			// The message is the last part and may contain pipes.
+			message := unescapeNewlines(strings.Join(parts[i:], "|")[2:])
+			lr.Body().InitEmpty()
+			lr.Body().SetStringVal(message)
+			attrs.InsertString(serviceCheckMessageAttribute, message)
+			i = len(parts)
+		default:
+			return lr, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	insertTags(attrs, tags)
+	lr.SetTimestamp(pdata.TimestampUnixNano(timestamp * 1e9))
+	return lr, nil
+}
+
+// insertTags inserts the DogStatsD tags as attributes. Tags without a value are
+// inserted with an empty value.
+func insertTags(attrs pdata.AttributeMap, tags []string) {
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		tagParts := strings.SplitN(tag, ":", 2)
+		value := ""
+		if len(tagParts) == 2 {
+			value = tagParts[1]
+		}
+		attrs.InsertString(tagParts[0], value)
+	}
+}
+
+// unescapeNewlines replaces the escaped newlines of DogStatsD texts.
+func unescapeNewlines(text string) string {
+	return strings.ReplaceAll(text, "\\n", "\n")
+}
@@ -0,0 +1,212 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// logRecordAttributes returns the attributes of the log record as a plain map.
+func logRecordAttributes(lr pdata.LogRecord) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueINT:
+			attrs[k] = v.IntVal()
+		default:
+			attrs[k] = v.StringVal()
+		}
+	})
+	return attrs
+}
+
+func Test_parseDogStatsDEvent(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		wantName         string
+		wantBody         string
+		wantSeverityText string
+		wantSeverity     pdata.SeverityNumber
+		wantTimestamp    pdata.TimestampUnixNano
+		wantAttributes   map[string]interface{}
+		err              error
+	}{
+		{
+			name:             "minimal event",
+			input:            "_e{5,4}:title|text",
+			wantName:         "title",
+			wantBody:         "text",
+			wantSeverityText: "Info",
+			wantSeverity:     pdata.SeverityNumberINFO,
+			wantTimestamp:    10e9,
+			wantAttributes: map[string]interface{}{
+				"event.title":      "title",
+				"event.text":       "text",
+				"event.priority":   "normal",
+				"event.alert_type": "info",
+			},
+		},
+		{
+			name:             "event with all fields",
+			input:            "_e{9,11}:the title|line\\nother|d:1600000000|h:myhost|p:low|t:warning|k:agg|s:source|#key:value,flag",
+			wantName:         "the title",
+			wantBody:         "line\nother",
+			wantSeverityText: "Warn",
+			wantSeverity:     pdata.SeverityNumberWARN,
+			wantTimestamp:    1600000000e9,
+			wantAttributes: map[string]interface{}{
+				"event.title":            "the title",
+				"event.text":             "line\nother",
+				"event.priority":         "low",
+				"event.alert_type":       "warning",
+				"event.aggregation_key":  "agg",
+				"event.source_type_name": "source",
+				"host.hostname":          "myhost",
+				"key":                    "value",
+				"flag":                   "",
+			},
+		},
+		{
+			name:             "error event with pipe in text",
+			input:            "_e{5,3}:title|a|b|t:error",
+			wantName:         "title",
+			wantBody:         "a|b",
+			wantSeverityText: "Error",
+			wantSeverity:     pdata.SeverityNumberERROR,
+			wantTimestamp:    10e9,
+			wantAttributes: map[string]interface{}{
+				"event.title":      "title",
+				"event.text":       "a|b",
+				"event.priority":   "normal",
+				"event.alert_type": "error",
+			},
+		},
+		{
+			name:  "invalid event header",
+			input: "_e{5,4}title|text",
+			err:   errors.New("invalid event format: _e{5,4}title|text"),
+		},
+		{
+			name:  "invalid event title length",
+			input: "_e{x,4}:title|text",
+			err:   errors.New("invalid event title length: x"),
+		},
+		{
+			name:  "event lengths mismatch",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "unsupported event alert type",
+			input: "_e{5,4}:title|text|t:critical",
+			err:   errors.New("unsupported event alert type: critical"),
+		},
+		{
+			name:  "unrecognized event part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+		{
+			name:             "minimal service check",
+			input:            "_sc|test.check|0",
+			wantName:         "test.check",
+			wantSeverityText: "Info",
+			wantSeverity:     pdata.SeverityNumberINFO,
+			wantTimestamp:    10e9,
+			wantAttributes: map[string]interface{}{
+				"service_check.name":   "test.check",
+				"service_check.status": int64(0),
+			},
+		},
+		{
+			name:             "service check with all fields",
+			input:            "_sc|test.check|2|d:1600000000|h:myhost|#key:value|m:failed|again",
+			wantName:         "test.check",
+			wantBody:         "failed|again",
+			wantSeverityText: "Error",
+			wantSeverity:     pdata.SeverityNumberERROR,
+			wantTimestamp:    1600000000e9,
+			wantAttributes: map[string]interface{}{
+				"service_check.name":    "test.check",
+				"service_check.status":  int64(2),
+				"service_check.message": "failed|again",
+				"host.hostname":         "myhost",
+				"key":                   "value",
+			},
+		},
+		{
+			name:           "unknown service check",
+			input:          "_sc|test.check|3",
+			wantName:       "test.check",
+			wantTimestamp:  10e9,
+			wantAttributes: map[string]interface{}{"service_check.name": "test.check", "service_check.status": int64(3)},
+		},
+		{
+			name:  "missing service check status",
+			input: "_sc|test.check",
+			err:   errors.New("invalid service check format: _sc|test.check"),
+		},
+		{
+			name:  "invalid service check status",
+			input: "_sc|test.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, err := parseDogStatsDEvent(tt.input, 10)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, lr.Name())
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, lr.Body().StringVal())
+			}
+			assert.Equal(t, tt.wantSeverityText, lr.SeverityText())
+			assert.Equal(t, tt.wantSeverity, lr.SeverityNumber())
+			assert.Equal(t, tt.wantTimestamp, lr.Timestamp())
+			assert.Equal(t, tt.wantAttributes, logRecordAttributes(lr))
+		})
+	}
+}
+
+func Test_StatsDParser_GetLogs(t *testing.T) {
+	timeNowFunc = func() int64 {
+		return 10
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text"))
+	require.NoError(t, p.Aggregate("test.metric:42|c"))
+	require.NoError(t, p.Aggregate("_sc|test.check|0"))
+	assert.Error(t, p.Aggregate("_sc|test.check"))
+
+	logs := p.GetLogs()
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "title", logs.At(0).Name())
+	assert.Equal(t, "test.check", logs.At(1).Name())
+	assert.Len(t, p.GetMetrics(), 1)
+
+	assert.Equal(t, 0, p.GetLogs().Len())
+}
@@ -16,9 +16,21 @@ package protocol
 
 import (
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 // Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Lines are aggregated into the current interval until the metrics are retrieved.
+// DogStatsD events and service checks are buffered as log records.
 type Parser interface {
-	Parse(in string) (*metricspb.Metric, error)
+	// Aggregate parses the input StatsD string and adds it to the current interval.
+	Aggregate(in string) error
+
+	// GetMetrics returns the metrics aggregated in the current interval and starts
+	// a new one.
+	GetMetrics() []*metricspb.Metric
+
+	// GetLogs returns the log records converted from the events and service checks
+	// received since the last call.
+	GetLogs() pdata.LogSlice
 }
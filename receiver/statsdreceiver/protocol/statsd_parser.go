@@ -17,12 +17,16 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
@@ -30,12 +34,71 @@ var (
 	errEmptyMetricValue = errors.New("empty metric value")
 )
 
+// ObserverType selects how the observations of timers, histograms and distributions
+// are reported.
+type ObserverType string
+
+const (
+	// SummaryObserver reports the observations as a summary with percentiles.
+	SummaryObserver ObserverType = "summary"
+	// HistogramObserver reports the observations as an explicit bucket distribution.
+	HistogramObserver ObserverType = "histogram"
+)
+
+// summaryPercentiles are the percentiles reported for the summaries of each interval.
+var summaryPercentiles = []float64{0, 50, 90, 95, 99, 100}
+
+// gaugeExpiryIntervals is the number of intervals without any update after which the
+// last value of a gauge is forgotten, so that short-lived gauges don't accumulate.
+const gaugeExpiryIntervals = 10
+
+// DefaultHistogramBuckets are the bucket boundaries used for histograms and distributions
+// when none are configured.
+var DefaultHistogramBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// DefaultTimerHistogramBuckets are the bucket boundaries used for timers when none are
+// configured. They are those of DefaultHistogramBuckets, in milliseconds.
+var DefaultTimerHistogramBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// observationTypeNames are the names of the StatsD types of observations.
+var observationTypeNames = map[string]string{
+	"ms": "timer",
+	"h":  "histogram",
+	"d":  "distribution",
+}
+
 func getSupportedTypes() []string {
-	return []string{"c", "g", "ms"}
+	return []string{"c", "g", "ms", "h", "s", "d"}
 }
 
-// StatsDParser supports the Parse method for parsing StatsD messages with Tags.
-type StatsDParser struct{}
+// StatsDParser supports the Aggregate method for aggregating StatsD messages with Tags.
+// Counters are summed, gauges keep their last value, sets count their unique values and
+// the observations of timers, histograms and distributions are reported as summaries or
+// histograms over each interval. A StatsDParser is not safe for concurrent use.
+type StatsDParser struct {
+	// ObserverTypes maps the StatsD types of observations ("ms", "h" and "d") to the
+	// way they are reported. Timers default to summaries, histograms and distributions
+	// default to histograms.
+	ObserverTypes map[string]ObserverType
+	// HistogramBuckets are the explicit bucket boundaries of the reported histograms,
+	// in increasing order. Defaults to DefaultTimerHistogramBuckets for timers and
+	// to DefaultHistogramBuckets for histograms and distributions.
+	HistogramBuckets []float64
+
+	// aggregates holds the metrics of the current interval by aggregation key.
+	aggregates map[string]*aggregate
+	// keys holds the aggregation keys in the order they were first seen.
+	keys []string
+	// gauges holds the last value of each gauge, kept across intervals so that
+	// relative updates apply to the previous value.
+	gauges        map[string]gaugeValue
+	intervalStart int64
+	// interval is the number of intervals flushed so far.
+	interval int64
+	// logs holds the DogStatsD events and service checks received since the last
+	// call to GetLogs.
+	logs []pdata.LogRecord
+}
 
 type statsDMetric struct {
 	name             string
@@ -48,23 +111,164 @@ type statsDMetric struct {
 	labelValues      []*metricspb.LabelValue
 }
 
+// aggregate is the state of a single name, type and tag set in the current interval.
+type aggregate struct {
+	metric *statsDMetric
+	// counter is the sum of the counter values, scaled by their sample rate.
+	counter float64
+	// gauge is the last value of the gauge.
+	gauge float64
+	// set holds the unique values of the set.
+	set map[string]bool
+	// count and sum are the number and sum of the observed values, scaled by their
+	// sample rate.
+	count        float64
+	sum          float64
+	observations []observation
+}
+
+// gaugeValue is the last value of a gauge along with the interval it was last updated in.
+type gaugeValue struct {
+	value    float64
+	interval int64
+}
+
+// observation is a value of a timer, histogram or distribution along with the
+// number of values it stands for according to its sample rate.
+type observation struct {
+	value  float64
+	weight float64
+}
+
 var timeNowFunc = func() int64 {
 	return time.Now().Unix()
 }
 
-// Parse returns an OTLP metric representation of the input StatsD string.
-func (p *StatsDParser) Parse(line string) (*metricspb.Metric, error) {
+// Aggregate parses the input StatsD string and adds it to the current interval.
+func (p *StatsDParser) Aggregate(line string) error {
+	if isDogStatsDEvent(line) {
+		lr, err := parseDogStatsDEvent(line, timeNowFunc())
+		if err != nil {
+			return err
+		}
+		p.logs = append(p.logs, lr)
+		return nil
+	}
+
 	parsedMetric, err := parseMessageToMetric(line)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	metricPoint, err := buildPoint(parsedMetric)
+	if p.aggregates == nil {
+		p.aggregates = make(map[string]*aggregate)
+		if p.intervalStart == 0 {
+			p.intervalStart = timeNowFunc()
+		}
+	}
+	if p.gauges == nil {
+		p.gauges = make(map[string]gaugeValue)
+	}
+
+	key := aggregationKey(parsedMetric)
+	agg, ok := p.aggregates[key]
+	if !ok {
+		agg = &aggregate{metric: parsedMetric}
+	}
+
+	switch parsedMetric.statsdMetricType {
+	case "c":
+		err = aggregateCounter(agg, parsedMetric)
+	case "g":
+		err = p.aggregateGauge(key, agg, parsedMetric)
+	case "s":
+		aggregateSet(agg, parsedMetric)
+	case "ms", "h", "d":
+		err = aggregateObservation(agg, parsedMetric, p.observerType(parsedMetric.statsdMetricType))
+	default:
+		err = fmt.Errorf("unhandled metric type: %s", parsedMetric.statsdMetricType)
+	}
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if !ok {
+		p.aggregates[key] = agg
+		p.keys = append(p.keys, key)
+	}
+	return nil
+}
+
+// GetMetrics returns the metrics aggregated in the current interval and starts a new one.
+func (p *StatsDParser) GetMetrics() []*metricspb.Metric {
+	now := timeNowFunc()
+	start := &timestamppb.Timestamp{Seconds: p.intervalStart}
+	end := &timestamppb.Timestamp{Seconds: now}
+
+	metrics := make([]*metricspb.Metric, 0, len(p.keys))
+	for _, key := range p.keys {
+		agg := p.aggregates[key]
+		metrics = append(metrics, buildMetric(agg, start, end, p.histogramBuckets(agg.metric.statsdMetricType)))
+	}
+
+	p.aggregates = nil
+	p.keys = nil
+	p.intervalStart = now
+	p.interval++
+	p.expireGauges()
+	return metrics
+}
+
+// expireGauges forgets the gauges that were not updated in the last gaugeExpiryIntervals intervals.
+func (p *StatsDParser) expireGauges() {
+	for key, gauge := range p.gauges {
+		if p.interval-gauge.interval >= gaugeExpiryIntervals {
+			delete(p.gauges, key)
+		}
+	}
+}
+
+// GetLogs returns the DogStatsD events and service checks received since the last call.
+func (p *StatsDParser) GetLogs() pdata.LogSlice {
+	logs := pdata.NewLogSlice()
+	for _, lr := range p.logs {
+		logs.Append(lr)
+	}
+	p.logs = nil
+	return logs
+}
+
+// histogramBuckets returns the bucket boundaries of the histograms of the StatsD type.
+func (p *StatsDParser) histogramBuckets(statsdMetricType string) []float64 {
+	if len(p.HistogramBuckets) > 0 {
+		return p.HistogramBuckets
+	}
+	if statsdMetricType == "ms" {
+		return DefaultTimerHistogramBuckets
+	}
+	return DefaultHistogramBuckets
+}
+
+// observerType returns how the observations of the StatsD type are reported.
+func (p *StatsDParser) observerType(statsdMetricType string) ObserverType {
+	if observerType, ok := p.ObserverTypes[statsdMetricType]; ok {
+		return observerType
+	}
+	if statsdMetricType == "ms" {
+		return SummaryObserver
 	}
+	return HistogramObserver
+}
 
-	return buildMetric(parsedMetric, metricPoint), nil
+// aggregationKey identifies the metric by its name, type and tag set, regardless of
+// the order of the tags.
+func aggregationKey(parsedMetric *statsDMetric) string {
+	tags := make([]string, len(parsedMetric.labelKeys))
+	for i, labelKey := range parsedMetric.labelKeys {
+		tags[i] = labelKey.Key + ":" + parsedMetric.labelValues[i].Value
+	}
+	sort.Strings(tags)
+	return parsedMetric.name + "|" + parsedMetric.statsdMetricType + "|" + strings.Join(tags, ",")
 }
 
 func parseMessageToMetric(line string) (*statsDMetric, error) {
@@ -96,7 +300,6 @@ func parseMessageToMetric(line string) (*statsDMetric, error) {
 
 	additionalParts := parts[2:]
 	for _, part := range additionalParts {
-		// TODO: Sample rate doesn't currently have a place to go in the protocol
 		if strings.HasPrefix(part, "@") {
 			sampleRateStr := strings.TrimPrefix(part, "@")
 
@@ -141,7 +344,42 @@ func contains(slice []string, element string) bool {
 	return false
 }
 
-func buildMetric(metric *statsDMetric, point *metricspb.Point) *metricspb.Metric {
+func buildMetric(agg *aggregate, start, end *timestamppb.Timestamp, buckets []float64) *metricspb.Metric {
+	metric := agg.metric
+	timeseries := &metricspb.TimeSeries{
+		LabelValues: metric.labelValues,
+	}
+
+	point := &metricspb.Point{
+		Timestamp: end,
+	}
+	switch metric.metricType {
+	case metricspb.MetricDescriptor_CUMULATIVE_INT64:
+		timeseries.StartTimestamp = start
+		point.Value = &metricspb.Point_Int64Value{
+			Int64Value: int64(math.Round(agg.counter)),
+		}
+	case metricspb.MetricDescriptor_GAUGE_DOUBLE:
+		point.Value = &metricspb.Point_DoubleValue{
+			DoubleValue: agg.gauge,
+		}
+	case metricspb.MetricDescriptor_GAUGE_INT64:
+		point.Value = &metricspb.Point_Int64Value{
+			Int64Value: int64(len(agg.set)),
+		}
+	case metricspb.MetricDescriptor_SUMMARY:
+		timeseries.StartTimestamp = start
+		point.Value = &metricspb.Point_SummaryValue{
+			SummaryValue: buildSummaryValue(agg),
+		}
+	case metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION:
+		timeseries.StartTimestamp = start
+		point.Value = &metricspb.Point_DistributionValue{
+			DistributionValue: buildDistributionValue(agg, buckets),
+		}
+	}
+	timeseries.Points = []*metricspb.Point{point}
+
 	return &metricspb.Metric{
 		MetricDescriptor: &metricspb.MetricDescriptor{
 			Name:      metric.name,
@@ -149,94 +387,148 @@ func buildMetric(metric *statsDMetric, point *metricspb.Point) *metricspb.Metric
 			LabelKeys: metric.labelKeys,
 			Unit:      metric.unit,
 		},
-		Timeseries: []*metricspb.TimeSeries{
-			{
-				LabelValues: metric.labelValues,
-				Points: []*metricspb.Point{
-					point,
-				},
-			},
-		},
+		Timeseries: []*metricspb.TimeSeries{timeseries},
 	}
 }
 
-func buildPoint(parsedMetric *statsDMetric) (*metricspb.Point, error) {
-	now := &timestamppb.Timestamp{
-		Seconds: timeNowFunc(),
+func buildSummaryValue(agg *aggregate) *metricspb.SummaryValue {
+	values := make([]float64, len(agg.observations))
+	for i, o := range agg.observations {
+		values[i] = o.value
 	}
+	sort.Float64s(values)
 
-	switch parsedMetric.statsdMetricType {
-	case "c":
-		return buildCounterPoint(parsedMetric, now, func(parsedMetric *statsDMetric) {
-			parsedMetric.metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
-		})
-	case "g":
-		return buildGaugePoint(parsedMetric, now, func(parsedMetric *statsDMetric) {
-			parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
-		})
-	case "ms":
-		return buildTimerPoint(parsedMetric, now, func(parsedMetric *statsDMetric) {
-			parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
+	percentileValues := make([]*metricspb.SummaryValue_Snapshot_ValueAtPercentile, 0, len(summaryPercentiles))
+	for _, percentile := range summaryPercentiles {
+		percentileValues = append(percentileValues, &metricspb.SummaryValue_Snapshot_ValueAtPercentile{
+			Percentile: percentile,
+			Value:      percentileOf(values, percentile),
 		})
 	}
 
-	return nil, fmt.Errorf("unhandled metric type: %s", parsedMetric.statsdMetricType)
+	return &metricspb.SummaryValue{
+		Count: &wrapperspb.Int64Value{Value: int64(math.Round(agg.count))},
+		Sum:   &wrapperspb.DoubleValue{Value: agg.sum},
+		Snapshot: &metricspb.SummaryValue_Snapshot{
+			PercentileValues: percentileValues,
+		},
+	}
 }
 
-func buildCounterPoint(parsedMetric *statsDMetric, now *timestamppb.Timestamp,
-	metricTypeSetter func(parsedMetric *statsDMetric)) (*metricspb.Point, error) {
-	var point *metricspb.Point
-	var i int64
-
-	f, err := strconv.ParseFloat(parsedMetric.value, 64)
-	if err != nil {
-		return nil, fmt.Errorf("counter: parse metric value string: %s", parsedMetric.value)
+// buildDistributionValue counts the observations into the explicit buckets. A value
+// equal to a bucket boundary is counted in the bucket above the boundary.
+func buildDistributionValue(agg *aggregate, bounds []float64) *metricspb.DistributionValue {
+	weights := make([]float64, len(bounds)+1)
+	mean := 0.0
+	if agg.count > 0 {
+		mean = agg.sum / agg.count
 	}
-	i = int64(f)
-	if 0 < parsedMetric.sampleRate && parsedMetric.sampleRate < 1 {
-		i = int64(f / parsedMetric.sampleRate)
+	sumOfSquaredDeviation := 0.0
+	for _, o := range agg.observations {
+		weights[sort.Search(len(bounds), func(i int) bool { return o.value < bounds[i] })] += o.weight
+		sumOfSquaredDeviation += o.weight * (o.value - mean) * (o.value - mean)
 	}
-	point = &metricspb.Point{
-		Timestamp: now,
-		Value: &metricspb.Point_Int64Value{
-			Int64Value: i,
+
+	var count int64
+	buckets := make([]*metricspb.DistributionValue_Bucket, len(weights))
+	for i, weight := range weights {
+		buckets[i] = &metricspb.DistributionValue_Bucket{Count: int64(math.Round(weight))}
+		count += buckets[i].Count
+	}
+
+	return &metricspb.DistributionValue{
+		Count:                 count,
+		Sum:                   agg.sum,
+		SumOfSquaredDeviation: sumOfSquaredDeviation,
+		BucketOptions: &metricspb.DistributionValue_BucketOptions{
+			Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+				Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+					Bounds: bounds,
+				},
+			},
 		},
+		Buckets: buckets,
 	}
-	metricTypeSetter(parsedMetric)
-	return point, nil
 }
 
-func buildGaugePoint(parsedMetric *statsDMetric, now *timestamppb.Timestamp,
-	metricTypeSetter func(parsedMetric *statsDMetric)) (*metricspb.Point, error) {
-	var point *metricspb.Point
+// percentileOf returns the nearest-rank percentile of the sorted values.
+func percentileOf(sorted []float64, percentile float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// sampleRateMultiplier returns the factor to scale a sampled value by.
+func sampleRateMultiplier(parsedMetric *statsDMetric) float64 {
+	if 0 < parsedMetric.sampleRate && parsedMetric.sampleRate < 1 {
+		return 1 / parsedMetric.sampleRate
+	}
+	return 1
+}
 
+func aggregateCounter(agg *aggregate, parsedMetric *statsDMetric) error {
 	f, err := strconv.ParseFloat(parsedMetric.value, 64)
 	if err != nil {
-		return nil, fmt.Errorf("gauge: parse metric value string: %s", parsedMetric.value)
+		return fmt.Errorf("counter: parse metric value string: %s", parsedMetric.value)
 	}
-	point = &metricspb.Point{
-		Timestamp: now,
-		Value: &metricspb.Point_DoubleValue{
-			DoubleValue: f,
-		},
+	agg.counter += f * sampleRateMultiplier(parsedMetric)
+	parsedMetric.metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
+	return nil
+}
+
+// aggregateGauge sets the gauge to the parsed value, or adds the value to the previous
+// one when it is prefixed with a sign. The previous value is forgotten once the gauge
+// has not been updated for gaugeExpiryIntervals intervals.
+func (p *StatsDParser) aggregateGauge(key string, agg *aggregate, parsedMetric *statsDMetric) error {
+	f, err := strconv.ParseFloat(parsedMetric.value, 64)
+	if err != nil {
+		return fmt.Errorf("gauge: parse metric value string: %s", parsedMetric.value)
+	}
+	if strings.HasPrefix(parsedMetric.value, "+") || strings.HasPrefix(parsedMetric.value, "-") {
+		f += p.gauges[key].value
 	}
-	metricTypeSetter(parsedMetric)
-	return point, nil
+	p.gauges[key] = gaugeValue{value: f, interval: p.interval}
+	agg.gauge = f
+	parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
+	return nil
 }
 
-func buildTimerPoint(parsedMetric *statsDMetric, now *timestamppb.Timestamp, metricTypeSetter func(parsedMetric *statsDMetric)) (*metricspb.Point, error) {
-	var point *metricspb.Point
-	parsedMetric.unit = "ms"
+// aggregateSet adds the value to the unique values of the set.
+func aggregateSet(agg *aggregate, parsedMetric *statsDMetric) {
+	if agg.set == nil {
+		agg.set = make(map[string]bool)
+	}
+	agg.set[parsedMetric.value] = true
+	parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_INT64
+}
+
+// aggregateObservation adds the value of a timer, histogram or distribution to the
+// observations of the interval.
+func aggregateObservation(agg *aggregate, parsedMetric *statsDMetric, observerType ObserverType) error {
 	f, err := strconv.ParseFloat(parsedMetric.value, 64)
 	if err != nil {
-		return nil, fmt.Errorf("timer: failed to parse metric value to float: %s", parsedMetric.value)
+		return fmt.Errorf("%s: failed to parse metric value to float: %s", observationTypeNames[parsedMetric.statsdMetricType], parsedMetric.value)
 	}
-	point = &metricspb.Point{
-		Timestamp: now,
-		Value: &metricspb.Point_DoubleValue{
-			DoubleValue: f,
-		},
+	weight := sampleRateMultiplier(parsedMetric)
+	agg.count += weight
+	agg.sum += f * weight
+	agg.observations = append(agg.observations, observation{value: f, weight: weight})
+
+	if parsedMetric.statsdMetricType == "ms" {
+		parsedMetric.unit = "ms"
+	}
+	if observerType == HistogramObserver {
+		parsedMetric.metricType = metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION
+	} else {
+		parsedMetric.metricType = metricspb.MetricDescriptor_SUMMARY
 	}
-	metricTypeSetter(parsedMetric)
-	return point, nil
+	return nil
 }
@@ -20,12 +20,14 @@ import (
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-func Test_StatsDParser_Parse(t *testing.T) {
+func Test_StatsDParser_Aggregate(t *testing.T) {
 	timeNowFunc = func() int64 {
-		return 0
+		return 10
 	}
 
 	tests := []struct {
@@ -62,9 +64,10 @@ func Test_StatsDParser_Parse(t *testing.T) {
 				nil,
 				nil,
 				"",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_Int64Value{
 						Int64Value: 42,
@@ -79,9 +82,10 @@ func Test_StatsDParser_Parse(t *testing.T) {
 				nil,
 				nil,
 				"",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_Int64Value{
 						Int64Value: 42,
@@ -115,9 +119,10 @@ func Test_StatsDParser_Parse(t *testing.T) {
 					},
 				},
 				"",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_Int64Value{
 						Int64Value: 420,
@@ -141,12 +146,13 @@ func Test_StatsDParser_Parse(t *testing.T) {
 					},
 				},
 				"",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_Int64Value{
-						Int64Value: 52,
+						Int64Value: 53,
 					},
 				}),
 		},
@@ -167,9 +173,10 @@ func Test_StatsDParser_Parse(t *testing.T) {
 					},
 				},
 				"",
+				nil,
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_DoubleValue{
 						DoubleValue: 42,
@@ -193,9 +200,10 @@ func Test_StatsDParser_Parse(t *testing.T) {
 					},
 				},
 				"",
+				nil,
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
 					Value: &metricspb.Point_DoubleValue{
 						DoubleValue: 42,
@@ -211,16 +219,17 @@ func Test_StatsDParser_Parse(t *testing.T) {
 			name:  "timer metric with sample rate",
 			input: "test.timer:42.3|ms|@0.1",
 			wantMetric: testMetric("test.timer",
-				metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				metricspb.MetricDescriptor_SUMMARY,
 				nil,
 				nil,
 				"ms",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
-					Value: &metricspb.Point_DoubleValue{
-						DoubleValue: 42.3,
+					Value: &metricspb.Point_SummaryValue{
+						SummaryValue: testSummaryValue(10, 423, 42.3, 42.3, 42.3, 42.3, 42.3, 42.3),
 					},
 				}),
 		},
@@ -228,7 +237,7 @@ func Test_StatsDParser_Parse(t *testing.T) {
 			name:  "timer metric with sample rate and tag",
 			input: "test.timer:42|ms|@0.1|#key:value",
 			wantMetric: testMetric("test.timer",
-				metricspb.MetricDescriptor_GAUGE_DOUBLE,
+				metricspb.MetricDescriptor_SUMMARY,
 				[]*metricspb.LabelKey{
 					{
 						Key: "key",
@@ -241,12 +250,13 @@ func Test_StatsDParser_Parse(t *testing.T) {
 					},
 				},
 				"ms",
+				&timestamppb.Timestamp{Seconds: 10},
 				&metricspb.Point{
 					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
+						Seconds: 10,
 					},
-					Value: &metricspb.Point_DoubleValue{
-						DoubleValue: 42,
+					Value: &metricspb.Point_SummaryValue{
+						SummaryValue: testSummaryValue(10, 420, 42, 42, 42, 42, 42, 42),
 					},
 				}),
 		},
@@ -255,6 +265,88 @@ func Test_StatsDParser_Parse(t *testing.T) {
 			input: "test.metric:invalidValue|ms",
 			err:   errors.New("timer: failed to parse metric value to float: invalidValue"),
 		},
+		{
+			name:  "set metric",
+			input: "test.set:user1|s|#key:value",
+			wantMetric: testMetric("test.set",
+				metricspb.MetricDescriptor_GAUGE_INT64,
+				[]*metricspb.LabelKey{
+					{
+						Key: "key",
+					},
+				},
+				[]*metricspb.LabelValue{
+					{
+						Value:    "value",
+						HasValue: true,
+					},
+				},
+				"",
+				nil,
+				&metricspb.Point{
+					Timestamp: &timestamppb.Timestamp{
+						Seconds: 10,
+					},
+					Value: &metricspb.Point_Int64Value{
+						Int64Value: 1,
+					},
+				}),
+		},
+		{
+			name:  "histogram metric with sample rate",
+			input: "test.histogram:0.3|h|@0.5",
+			wantMetric: testMetric("test.histogram",
+				metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+				nil,
+				nil,
+				"",
+				&timestamppb.Timestamp{Seconds: 10},
+				&metricspb.Point{
+					Timestamp: &timestamppb.Timestamp{
+						Seconds: 10,
+					},
+					Value: &metricspb.Point_DistributionValue{
+						DistributionValue: testDistributionValue(DefaultHistogramBuckets, 0.6, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0),
+					},
+				}),
+		},
+		{
+			name:  "distribution metric with tag",
+			input: "test.distribution:20|d|#key:value",
+			wantMetric: testMetric("test.distribution",
+				metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+				[]*metricspb.LabelKey{
+					{
+						Key: "key",
+					},
+				},
+				[]*metricspb.LabelValue{
+					{
+						Value:    "value",
+						HasValue: true,
+					},
+				},
+				"",
+				&timestamppb.Timestamp{Seconds: 10},
+				&metricspb.Point{
+					Timestamp: &timestamppb.Timestamp{
+						Seconds: 10,
+					},
+					Value: &metricspb.Point_DistributionValue{
+						DistributionValue: testDistributionValue(DefaultHistogramBuckets, 20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1),
+					},
+				}),
+		},
+		{
+			name:  "histogram: invalid metric value",
+			input: "test.metric:invalidValue|h",
+			err:   errors.New("histogram: failed to parse metric value to float: invalidValue"),
+		},
+		{
+			name:  "distribution: invalid metric value",
+			input: "test.metric:invalidValue|d",
+			err:   errors.New("distribution: failed to parse metric value to float: invalidValue"),
+		},
 		{
 			name:  "invalid sample rate value",
 			input: "test.metric:42|c|@1.0a",
@@ -276,23 +368,242 @@ func Test_StatsDParser_Parse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &StatsDParser{}
 
-			got, err := p.Parse(tt.input)
+			err := p.Aggregate(tt.input)
 
 			if tt.err != nil {
 				assert.Equal(t, err, tt.err)
+				assert.Empty(t, p.GetMetrics())
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, got, tt.wantMetric)
+				assert.Equal(t, []*metricspb.Metric{tt.wantMetric}, p.GetMetrics())
 			}
 		})
 	}
 }
 
+func Test_StatsDParser_AggregateInterval(t *testing.T) {
+	now := int64(10)
+	timeNowFunc = func() int64 {
+		return now
+	}
+
+	p := &StatsDParser{}
+	for _, line := range []string{
+		"test.counter:1|c|#key:value,other:value",
+		"test.counter:2|c|@0.5|#other:value,key:value",
+		"test.counter:3|c|#key:another",
+		"test.gauge:10|g",
+		"test.gauge:-3|g",
+		"test.gauge:+1|g",
+		"test.timer:4|ms",
+		"test.timer:1|ms",
+		"test.timer:3|ms",
+		"test.timer:2|ms",
+	} {
+		require.NoError(t, p.Aggregate(line))
+	}
+
+	now = 20
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 4)
+
+	counterStart := &timestamppb.Timestamp{Seconds: 10}
+	counterPoint := &timestamppb.Timestamp{Seconds: 20}
+	assert.Equal(t, testMetric("test.counter",
+		metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		[]*metricspb.LabelKey{{Key: "key"}, {Key: "other"}},
+		[]*metricspb.LabelValue{{Value: "value", HasValue: true}, {Value: "value", HasValue: true}},
+		"",
+		counterStart,
+		&metricspb.Point{Timestamp: counterPoint, Value: &metricspb.Point_Int64Value{Int64Value: 5}},
+	), metrics[0])
+	assert.Equal(t, testMetric("test.counter",
+		metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		[]*metricspb.LabelKey{{Key: "key"}},
+		[]*metricspb.LabelValue{{Value: "another", HasValue: true}},
+		"",
+		counterStart,
+		&metricspb.Point{Timestamp: counterPoint, Value: &metricspb.Point_Int64Value{Int64Value: 3}},
+	), metrics[1])
+	assert.Equal(t, testMetric("test.gauge",
+		metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		nil,
+		nil,
+		"",
+		nil,
+		&metricspb.Point{Timestamp: counterPoint, Value: &metricspb.Point_DoubleValue{DoubleValue: 8}},
+	), metrics[2])
+	assert.Equal(t, testMetric("test.timer",
+		metricspb.MetricDescriptor_SUMMARY,
+		nil,
+		nil,
+		"ms",
+		counterStart,
+		&metricspb.Point{Timestamp: counterPoint, Value: &metricspb.Point_SummaryValue{
+			SummaryValue: testSummaryValue(4, 10, 1, 2, 4, 4, 4, 4),
+		}},
+	), metrics[3])
+
+	// The next interval starts empty, but relative gauge updates apply to the
+	// last value of the gauge.
+	assert.Empty(t, p.GetMetrics())
+	require.NoError(t, p.Aggregate("test.gauge:+2|g"))
+	now = 30
+	metrics = p.GetMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, testMetric("test.gauge",
+		metricspb.MetricDescriptor_GAUGE_DOUBLE,
+		nil,
+		nil,
+		"",
+		nil,
+		&metricspb.Point{Timestamp: &timestamppb.Timestamp{Seconds: 30}, Value: &metricspb.Point_DoubleValue{DoubleValue: 10}},
+	), metrics[0])
+}
+
+func Test_StatsDParser_GaugeExpiry(t *testing.T) {
+	timeNowFunc = func() int64 {
+		return 10
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Aggregate("test.gauge:5|g"))
+	for i := 0; i < gaugeExpiryIntervals-1; i++ {
+		p.GetMetrics()
+	}
+
+	// A gauge updated before it expires keeps its last value.
+	require.NoError(t, p.Aggregate("test.gauge:+1|g"))
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, 6.0, metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+
+	for i := 0; i < gaugeExpiryIntervals; i++ {
+		p.GetMetrics()
+	}
+	assert.Empty(t, p.gauges)
+
+	// Relative updates of an expired gauge start from zero.
+	require.NoError(t, p.Aggregate("test.gauge:+1|g"))
+	metrics = p.GetMetrics()
+	require.Len(t, metrics, 1)
+	assert.Equal(t, 1.0, metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+}
+
+func Test_StatsDParser_SampledTimerCount(t *testing.T) {
+	timeNowFunc = func() int64 {
+		return 10
+	}
+
+	p := &StatsDParser{}
+	for i := 0; i < 9; i++ {
+		require.NoError(t, p.Aggregate("test.timer:1|ms|@0.9"))
+	}
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 1)
+
+	// the weights of the observations add up to slightly less than 10
+	summary := metrics[0].Timeseries[0].Points[0].GetSummaryValue()
+	assert.Equal(t, int64(10), summary.GetCount().GetValue())
+	assert.InDelta(t, 10, summary.GetSum().GetValue(), 1e-9)
+}
+
+func Test_StatsDParser_ObserverTypes(t *testing.T) {
+	timeNowFunc = func() int64 {
+		return 10
+	}
+
+	p := &StatsDParser{
+		ObserverTypes: map[string]ObserverType{
+			"ms": HistogramObserver,
+			"h":  SummaryObserver,
+		},
+		HistogramBuckets: []float64{10, 100},
+	}
+	for _, line := range []string{
+		"test.timer:5|ms",
+		"test.timer:10|ms",
+		"test.timer:50|ms",
+		"test.timer:500|ms|@0.5",
+		"test.histogram:1|h",
+		"test.histogram:3|h",
+		"test.set:a|s",
+		"test.set:b|s",
+		"test.set:a|s",
+	} {
+		require.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 3)
+
+	start := &timestamppb.Timestamp{Seconds: 10}
+	end := &timestamppb.Timestamp{Seconds: 10}
+	timerDistribution := testDistributionValue([]float64{10, 100}, 1065, 1, 2, 2)
+	// The mean is 1065 / 5 = 213, the value 500 is counted twice.
+	timerDistribution.SumOfSquaredDeviation = (5-213)*(5-213) + (10-213)*(10-213) + (50-213)*(50-213) + 2*(500-213)*(500-213)
+	assert.Equal(t, testMetric("test.timer",
+		metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+		nil,
+		nil,
+		"ms",
+		start,
+		&metricspb.Point{Timestamp: end, Value: &metricspb.Point_DistributionValue{
+			DistributionValue: timerDistribution,
+		}},
+	), metrics[0])
+	assert.Equal(t, testMetric("test.histogram",
+		metricspb.MetricDescriptor_SUMMARY,
+		nil,
+		nil,
+		"",
+		start,
+		&metricspb.Point{Timestamp: end, Value: &metricspb.Point_SummaryValue{
+			SummaryValue: testSummaryValue(2, 4, 1, 1, 3, 3, 3, 3),
+		}},
+	), metrics[1])
+	assert.Equal(t, testMetric("test.set",
+		metricspb.MetricDescriptor_GAUGE_INT64,
+		nil,
+		nil,
+		"",
+		nil,
+		&metricspb.Point{Timestamp: end, Value: &metricspb.Point_Int64Value{Int64Value: 2}},
+	), metrics[2])
+}
+
+func Test_StatsDParser_DefaultHistogramBuckets(t *testing.T) {
+	timeNowFunc = func() int64 {
+		return 10
+	}
+
+	p := &StatsDParser{
+		ObserverTypes: map[string]ObserverType{
+			"ms": HistogramObserver,
+		},
+	}
+	require.NoError(t, p.Aggregate("test.timer:30|ms"))
+	require.NoError(t, p.Aggregate("test.histogram:0.03|h"))
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 2)
+
+	// Timers are in milliseconds, the observations land in the same bucket.
+	assert.Equal(t,
+		testDistributionValue(DefaultTimerHistogramBuckets, 30, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0),
+		metrics[0].Timeseries[0].Points[0].GetDistributionValue())
+	assert.Equal(t,
+		testDistributionValue(DefaultHistogramBuckets, 0.03, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0),
+		metrics[1].Timeseries[0].Points[0].GetDistributionValue())
+}
+
 func testMetric(metricName string,
 	metricType metricspb.MetricDescriptor_Type,
 	lableKeys []*metricspb.LabelKey,
 	labelValues []*metricspb.LabelValue,
 	unit string,
+	startTimestamp *timestamppb.Timestamp,
 	point *metricspb.Point) *metricspb.Metric {
 	return &metricspb.Metric{
 		MetricDescriptor: &metricspb.MetricDescriptor{
@@ -303,7 +614,8 @@ func testMetric(metricName string,
 		},
 		Timeseries: []*metricspb.TimeSeries{
 			{
-				LabelValues: labelValues,
+				StartTimestamp: startTimestamp,
+				LabelValues:    labelValues,
 				Points: []*metricspb.Point{
 					point,
 				},
@@ -311,3 +623,43 @@ func testMetric(metricName string,
 		},
 	}
 }
+
+// testSummaryValue builds a summary with the values of the 0, 50, 90, 95, 99
+// and 100 percentiles.
+func testSummaryValue(count int64, sum float64, percentileValues ...float64) *metricspb.SummaryValue {
+	snapshot := &metricspb.SummaryValue_Snapshot{}
+	for i, percentile := range []float64{0, 50, 90, 95, 99, 100} {
+		snapshot.PercentileValues = append(snapshot.PercentileValues, &metricspb.SummaryValue_Snapshot_ValueAtPercentile{
+			Percentile: percentile,
+			Value:      percentileValues[i],
+		})
+	}
+	return &metricspb.SummaryValue{
+		Count:    &wrapperspb.Int64Value{Value: count},
+		Sum:      &wrapperspb.DoubleValue{Value: sum},
+		Snapshot: snapshot,
+	}
+}
+
+// testDistributionValue builds a distribution with the given bucket counts and no
+// squared deviation.
+func testDistributionValue(bounds []float64, sum float64, bucketCounts ...int64) *metricspb.DistributionValue {
+	var count int64
+	buckets := make([]*metricspb.DistributionValue_Bucket, len(bucketCounts))
+	for i, bucketCount := range bucketCounts {
+		buckets[i] = &metricspb.DistributionValue_Bucket{Count: bucketCount}
+		count += bucketCount
+	}
+	return &metricspb.DistributionValue{
+		Count: count,
+		Sum:   sum,
+		BucketOptions: &metricspb.DistributionValue_BucketOptions{
+			Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+				Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{
+					Bounds: bounds,
+				},
+			},
+		},
+		Buckets: buckets,
+	}
+}
@@ -19,10 +19,14 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
@@ -30,17 +34,31 @@ import (
 )
 
 var _ component.MetricsReceiver = (*statsdReceiver)(nil)
+var _ component.LogsReceiver = (*statsdReceiver)(nil)
 
-// statsdReceiver implements the component.MetricsReceiver for StatsD protocol.
+// statsdReceiver implements the component.MetricsReceiver and component.LogsReceiver
+// for StatsD protocol. Metrics are sent to the metrics consumer and DogStatsD events
+// and service checks to the logs consumer.
 type statsdReceiver struct {
 	sync.Mutex
 	logger *zap.Logger
 	config *Config
 
-	server       transport.Server
-	reporter     transport.Reporter
-	parser       protocol.Parser
-	nextConsumer consumer.MetricsConsumer
+	server          transport.Server
+	reporter        transport.Reporter
+	parser          protocol.Parser
+	metricsConsumer consumer.MetricsConsumer
+	logsConsumer    consumer.LogsConsumer
+
+	// transferChan carries the lines received by the server to the aggregation loop.
+	transferChan chan string
+	serverWG     sync.WaitGroup
+	aggregateWG  sync.WaitGroup
+
+	// numReceivedMessages and numInvalidMessages count the lines of the current
+	// interval. They are only accessed by the aggregation loop.
+	numReceivedMessages int
+	numInvalidMessages  int
 
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -56,10 +74,42 @@ func New(
 		return nil, componenterror.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(nextConsumer)
+	return r, nil
+}
+
+// newReceiver creates the StatsD receiver without any consumer. The consumers are
+// registered with RegisterMetricsConsumer and RegisterLogsConsumer.
+func newReceiver(logger *zap.Logger, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
 
+	if config.NetAddr.Transport == "" {
+		config.NetAddr.Transport = defaultTransport
+	}
+
+	if config.AggregationInterval <= 0 {
+		config.AggregationInterval = defaultAggregationInterval
+	}
+
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = defaultIdleTimeout
+	}
+
+	if config.MaxLineLength <= 0 {
+		config.MaxLineLength = defaultMaxLineLength
+	}
+
+	parser, err := buildParser(config)
+	if err != nil {
+		return nil, err
+	}
+
 	server, err := buildTransportServer(config)
 	if err != nil {
 		return nil, err
@@ -68,25 +118,79 @@ func New(
 	r := &statsdReceiver{
 		logger:       logger,
 		config:       &config,
-		nextConsumer: nextConsumer,
 		server:       server,
-		reporter:     newReporter(config.Name(), logger),
-		parser:       &protocol.StatsDParser{},
+		reporter:     newReporter(config.Name(), strings.ToLower(config.NetAddr.Transport), logger),
+		parser:       parser,
+		transferChan: make(chan string, 100),
 	}
 	return r, nil
 }
 
+// RegisterMetricsConsumer sets the consumer of the received metrics.
+func (r *statsdReceiver) RegisterMetricsConsumer(mc consumer.MetricsConsumer) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.metricsConsumer = mc
+}
+
+// RegisterLogsConsumer sets the consumer of the received DogStatsD events and
+// service checks.
+func (r *statsdReceiver) RegisterLogsConsumer(lc consumer.LogsConsumer) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.logsConsumer = lc
+}
+
+// statsdTypes maps the StatsD types of observations in the configuration to their
+// type in the StatsD messages.
+var statsdTypes = map[string]string{
+	"timer":        "ms",
+	"histogram":    "h",
+	"distribution": "d",
+}
+
+func buildParser(config Config) (protocol.Parser, error) {
+	parser := &protocol.StatsDParser{
+		ObserverTypes: make(map[string]protocol.ObserverType, len(config.TimerHistogramMapping)),
+	}
+
+	for _, mapping := range config.TimerHistogramMapping {
+		statsdType, ok := statsdTypes[mapping.StatsdType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported statsd_type %q for receiver %q", mapping.StatsdType, config.Name())
+		}
+		if mapping.ObserverType != protocol.SummaryObserver && mapping.ObserverType != protocol.HistogramObserver {
+			return nil, fmt.Errorf("unsupported observer_type %q for statsd_type %q of receiver %q", mapping.ObserverType, mapping.StatsdType, config.Name())
+		}
+		parser.ObserverTypes[statsdType] = mapping.ObserverType
+	}
+
+	for i := 1; i < len(config.HistogramBuckets); i++ {
+		if config.HistogramBuckets[i] <= config.HistogramBuckets[i-1] {
+			return nil, fmt.Errorf("histogram_buckets of receiver %q must be in increasing order", config.Name())
+		}
+	}
+	parser.HistogramBuckets = config.HistogramBuckets
+
+	return parser, nil
+}
+
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
-	case "", "udp":
+	case "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.IdleTimeout, config.MaxLineLength)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q for receiver %q", config.NetAddr.Transport, config.Name())
 }
 
-// StartMetricsReception starts a UDP server that can process StatsD messages.
+// StartMetricsReception starts a server that can process StatsD messages.
 func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
 	r.Lock()
 	defer r.Unlock()
@@ -94,18 +198,91 @@ func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
 	err := componenterror.ErrAlreadyStarted
 	r.startOnce.Do(func() {
 		err = nil
+		r.serverWG.Add(1)
 		go func() {
-			err = r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter)
-			if err != nil {
+			defer r.serverWG.Done()
+			if err := r.server.ListenAndServe(r.transferChan, r.reporter); err != nil {
 				host.ReportFatalError(err)
 			}
 		}()
+
+		r.aggregateWG.Add(1)
+		go r.aggregateLoop()
 	})
 
 	return err
 }
 
-// StopMetricsReception stops the StatsD receiver.
+// aggregateLoop aggregates the received lines and flushes the aggregated metrics
+// every AggregationInterval, until transferChan is closed.
+func (r *statsdReceiver) aggregateLoop() {
+	defer r.aggregateWG.Done()
+
+	ticker := time.NewTicker(r.config.AggregationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.flush()
+		case line, ok := <-r.transferChan:
+			if !ok {
+				r.flush()
+				return
+			}
+			r.numReceivedMessages++
+			if err := r.parser.Aggregate(line); err != nil {
+				r.numInvalidMessages++
+				r.reporter.OnTranslationError(context.Background(), err)
+			}
+		}
+	}
+}
+
+// flush sends the metrics aggregated in the current interval to the metrics consumer
+// and the received events and service checks to the logs consumer. Without a
+// registered consumer the corresponding data is dropped.
+func (r *statsdReceiver) flush() {
+	metrics := r.parser.GetMetrics()
+	logs := r.parser.GetLogs()
+	if len(metrics) == 0 && logs.Len() == 0 && r.numReceivedMessages == 0 {
+		return
+	}
+
+	ctx := r.reporter.OnDataReceived(context.Background())
+	var errs []error
+	if len(metrics) > 0 && r.metricsConsumer != nil {
+		md := consumerdata.MetricsData{
+			Metrics: metrics,
+		}
+		if err := r.metricsConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(md)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if logs.Len() > 0 && r.logsConsumer != nil {
+		if err := r.logsConsumer.ConsumeLogs(ctx, buildLogs(logs)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.reporter.OnMetricsProcessed(ctx, r.numReceivedMessages, r.numInvalidMessages, componenterror.CombineErrors(errs))
+
+	r.numReceivedMessages = 0
+	r.numInvalidMessages = 0
+}
+
+// buildLogs wraps the log records into a single resource and instrumentation library.
+func buildLogs(logSlice pdata.LogSlice) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	ills := rls.At(0).InstrumentationLibraryLogs()
+	ills.Resize(1)
+	logSlice.MoveAndAppendTo(ills.At(0).Logs())
+	return ld
+}
+
+// StopMetricsReception stops the StatsD receiver, flushing the metrics aggregated
+// so far.
 func (r *statsdReceiver) Shutdown(context.Context) error {
 	r.Lock()
 	defer r.Unlock()
@@ -113,6 +290,9 @@ func (r *statsdReceiver) Shutdown(context.Context) error {
 	var err = componenterror.ErrAlreadyStopped
 	r.stopOnce.Do(func() {
 		err = r.server.Close()
+		r.serverWG.Wait()
+		close(r.transferChan)
+		r.aggregateWG.Wait()
 	})
 	return err
 }
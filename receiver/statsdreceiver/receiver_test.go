@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,10 +30,12 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport/client"
 )
@@ -85,6 +88,43 @@ func Test_statsdreceiver_New(t *testing.T) {
 			},
 			wantErr: errors.New("unsupported transport \"unknown\" for receiver \"statsd\""),
 		},
+		{
+			name: "unsupported statsd type",
+			args: args{
+				config: Config{
+					ReceiverSettings: defaultConfig.ReceiverSettings,
+					TimerHistogramMapping: []TimerHistogramMapping{
+						{StatsdType: "counter", ObserverType: protocol.HistogramObserver},
+					},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+			wantErr: errors.New("unsupported statsd_type \"counter\" for receiver \"statsd\""),
+		},
+		{
+			name: "unsupported observer type",
+			args: args{
+				config: Config{
+					ReceiverSettings: defaultConfig.ReceiverSettings,
+					TimerHistogramMapping: []TimerHistogramMapping{
+						{StatsdType: "timer", ObserverType: "gauge"},
+					},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+			wantErr: errors.New("unsupported observer_type \"gauge\" for statsd_type \"timer\" of receiver \"statsd\""),
+		},
+		{
+			name: "unsorted histogram buckets",
+			args: args{
+				config: Config{
+					ReceiverSettings: defaultConfig.ReceiverSettings,
+					HistogramBuckets: []float64{1, 10, 5},
+				},
+				nextConsumer: consumertest.NewMetricsNop(),
+			},
+			wantErr: errors.New("histogram_buckets of receiver \"statsd\" must be in increasing order"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,7 +155,9 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		{
 			name: "default_config",
 			configFn: func() *Config {
-				return createDefaultConfig().(*Config)
+				cfg := createDefaultConfig().(*Config)
+				cfg.AggregationInterval = 100 * time.Millisecond
+				return cfg
 			},
 			clientFn: func(t *testing.T) *client.StatsD {
 				c, err := client.NewStatsD(client.UDP, host, port)
@@ -123,6 +165,20 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "tcp",
+			configFn: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.NetAddr.Transport = "tcp"
+				cfg.AggregationInterval = 100 * time.Millisecond
+				return cfg
+			},
+			clientFn: func(t *testing.T) *client.StatsD {
+				c, err := client.NewStatsD(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,6 +198,7 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 			require.Equal(t, componenterror.ErrAlreadyStarted, r.Start(context.Background(), componenttest.NewNopHost()))
 
 			statsdClient := tt.clientFn(t)
+			defer statsdClient.Disconnect()
 
 			statsdMetric := client.Metric{
 				Name:  "test.metric",
@@ -162,9 +219,53 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 			assert.Equal(t, statsdMetric.Name, metric.GetMetricDescriptor().GetName())
 			tss := metric.GetTimeseries()
 			require.Equal(t, 1, len(tss))
+			require.Equal(t, 1, len(tss[0].GetPoints()))
+			assert.Equal(t, int64(42), tss[0].GetPoints()[0].GetInt64Value())
 
 			assert.NoError(t, r.Shutdown(context.Background()))
 			assert.Equal(t, componenterror.ErrAlreadyStopped, r.Shutdown(context.Background()))
 		})
 	}
 }
+
+func Test_statsdreceiver_Logs(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = addr
+	cfg.AggregationInterval = 100 * time.Millisecond
+
+	r, err := newReceiver(zap.NewNop(), *cfg)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	r.RegisterMetricsConsumer(metricsSink)
+	r.RegisterLogsConsumer(logsSink)
+
+	mr := transport.NewMockReporter(1)
+	r.reporter = mr
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("test.metric:42|c\n_e{5,4}:title|text|t:error\n_sc|test.check|1|m:failing"))
+	require.NoError(t, err)
+
+	mr.WaitAllOnMetricsProcessedCalls()
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, 1, metricsSink.AllMetrics()[0].MetricCount())
+
+	lds := logsSink.AllLogs()
+	require.Len(t, lds, 1)
+	logs := lds[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "title", logs.At(0).Name())
+	assert.Equal(t, "text", logs.At(0).Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumberERROR, logs.At(0).SeverityNumber())
+	assert.Equal(t, "test.check", logs.At(1).Name())
+	assert.Equal(t, "failing", logs.At(1).Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumberWARN, logs.At(1).SeverityNumber())
+}
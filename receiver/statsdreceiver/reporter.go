@@ -28,6 +28,7 @@ import (
 // observability per Collector metric observability package.
 type reporter struct {
 	name          string
+	transport     string
 	spanName      string
 	logger        *zap.Logger
 	sugaredLogger *zap.SugaredLogger // Used for generic debug logging
@@ -35,9 +36,10 @@ type reporter struct {
 
 var _ (transport.Reporter) = (*reporter)(nil)
 
-func newReporter(receiverName string, logger *zap.Logger) transport.Reporter {
+func newReporter(receiverName string, transport string, logger *zap.Logger) transport.Reporter {
 	return &reporter{
 		name:          receiverName,
+		transport:     transport,
 		spanName:      receiverName + ".receiver",
 		logger:        logger,
 		sugaredLogger: logger.Sugar(),
@@ -49,8 +51,8 @@ func newReporter(receiverName string, logger *zap.Logger) transport.Reporter {
 // reporter instance. The caller code should include a call to end the
 // returned span.
 func (r *reporter) OnDataReceived(ctx context.Context) context.Context {
-	ctx = obsreport.ReceiverContext(ctx, r.name, "tcp", r.name)
-	return obsreport.StartMetricsReceiveOp(ctx, r.name, "tcp")
+	ctx = obsreport.ReceiverContext(ctx, r.name, r.transport, r.name)
+	return obsreport.StartMetricsReceiveOp(ctx, r.name, r.transport)
 }
 
 // OnTranslationError is used to report a translation error from original
@@ -30,18 +30,18 @@ func TestReporterObservability(t *testing.T) {
 	defer doneFn()
 
 	const receiverName = "fake_statsd_receiver"
-	reporter := newReporter(receiverName, zap.NewNop())
+	reporter := newReporter(receiverName, "udp", zap.NewNop())
 
 	ctx := reporter.OnDataReceived(context.Background())
 
 	reporter.OnMetricsProcessed(ctx, 17, 13, nil)
 
-	obsreporttest.CheckReceiverMetricsViews(t, receiverName, "tcp", 17, 0)
+	obsreporttest.CheckReceiverMetricsViews(t, receiverName, "udp", 17, 0)
 
 	// Below just exercise the error paths.
 	err = errors.New("fake error for tests")
 	reporter.OnTranslationError(ctx, err)
 	reporter.OnMetricsProcessed(ctx, 10, 10, err)
 
-	obsreporttest.CheckReceiverMetricsViews(t, receiverName, "tcp", 17, 10)
+	obsreporttest.CheckReceiverMetricsViews(t, receiverName, "udp", 17, 10)
 }
@@ -0,0 +1,150 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+// packetServer is a transport.Server for datagram transports, where each
+// datagram holds one or more newline separated lines.
+type packetServer struct {
+	packetConn net.PacketConn
+	reporter   Reporter
+	// transport is the name of the transport used in debug messages.
+	transport string
+	// socketPath is the path of the unix socket to remove on Close, if any.
+	socketPath string
+}
+
+var _ (Server) = (*packetServer)(nil)
+
+// NewUDPServer creates a transport.Server using UDP as its transport.
+func NewUDPServer(addr string) (Server, error) {
+	packetConn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
+		packetConn: packetConn,
+		transport:  "UDP",
+	}
+	return &u, nil
+}
+
+// NewUnixgramServer creates a transport.Server using a unix datagram socket
+// created at path as its transport. A socket left at path by a previous run
+// that didn't get to close it is removed first.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
+		packetConn: packetConn,
+		transport:  "Unixgram",
+		socketPath: path,
+	}
+	return &u, nil
+}
+
+// removeStaleSocket removes the unix socket at path, if any. Any other kind of
+// file is left alone, so that listening fails instead of deleting it.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+func (u *packetServer) ListenAndServe(
+	transferChan chan<- string,
+	reporter Reporter,
+) error {
+	if transferChan == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	u.reporter = reporter
+
+	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
+	for {
+		n, _, err := u.packetConn.ReadFrom(buf)
+		if n > 0 {
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			u.handlePacket(bufCopy, transferChan)
+		}
+		if err != nil {
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				u.transport,
+				u.packetConn.LocalAddr(),
+				err)
+			if netErr, ok := err.(net.Error); ok {
+				if netErr.Temporary() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	if u.socketPath != "" {
+		if rmErr := os.Remove(u.socketPath); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+func (u *packetServer) handlePacket(
+	data []byte,
+	transferChan chan<- string,
+) {
+	buf := bytes.NewBuffer(data)
+	for {
+		bytes, err := buf.ReadBytes((byte)('\n'))
+		if err == io.EOF {
+			if len(bytes) == 0 {
+				// Completed without errors.
+				break
+			}
+		}
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			transferChan <- line
+		}
+	}
+}
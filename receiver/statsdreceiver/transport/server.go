@@ -17,10 +17,6 @@ package transport
 import (
 	"context"
 	"errors"
-
-	"go.opentelemetry.io/collector/consumer"
-
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 var (
@@ -31,16 +27,15 @@ var (
 // interface to handle serving clients over that transport.
 type Server interface {
 	// ListenAndServe is a blocking call that starts to listen for client messages
-	// on the specific transport, and prepares the message to be processed by
-	// the Parser and passed to the next consumer.
+	// on the specific transport, and sends each received line to transferChan
+	// to be aggregated by the Parser.
 	ListenAndServe(
-		p protocol.Parser,
-		mc consumer.MetricsConsumer,
+		transferChan chan<- string,
 		r Reporter,
 	) error
 
 	// Close stops any running ListenAndServe, however, it waits for any
-	// data already received to be parsed and sent to the next consumer.
+	// data already received to be sent to transferChan.
 	Close() error
 }
 
@@ -15,65 +15,89 @@
 package transport
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/testutil"
-	"go.opentelemetry.io/collector/translator/internaldata"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport/client"
 )
 
 func Test_Server_ListenAndServe(t *testing.T) {
 	tests := []struct {
 		name          string
+		addrFn        func(t *testing.T) string
 		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		buildClientFn func(t *testing.T, addr string) (*client.StatsD, error)
 	}{
 		{
-			name: "udp",
+			name:   "udp",
+			addrFn: testutil.GetAvailableLocalAddress,
 			buildServerFn: func(addr string) (Server, error) {
 				return NewUDPServer(addr)
 			},
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name:   "tcp",
+			addrFn: testutil.GetAvailableLocalAddress,
+			buildServerFn: func(addr string) (Server, error) {
+				return NewTCPServer(addr, time.Minute, 1024)
+			},
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name:   "unixgram",
+			addrFn: socketPath,
+			buildServerFn: func(addr string) (Server, error) {
+				return NewUnixgramServer(addr)
+			},
+			buildClientFn: func(t *testing.T, addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unixgram", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalAddress(t)
+			addr := tt.addrFn(t)
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
-			mc := new(consumertest.MetricsSink)
-			p := &protocol.StatsDParser{}
-			require.NoError(t, err)
-			mr := NewMockReporter(1)
+			transferChan := make(chan string, 10)
+			mr := NewMockReporter(0)
 
 			wgListenAndServe := sync.WaitGroup{}
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(p, mc, mr))
+				assert.Error(t, srv.ListenAndServe(transferChan, mr))
 			}()
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(t, addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 
@@ -88,23 +112,115 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			err = gc.Disconnect()
 			assert.NoError(t, err)
 
-			mr.WaitAllOnMetricsProcessedCalls()
+			line := <-transferChan
+			assert.Equal(t, "test.metric:42|c", line)
 
 			err = srv.Close()
 			assert.NoError(t, err)
 
 			wgListenAndServe.Wait()
+		})
+	}
+}
 
-			mdd := mc.AllMetrics()
-			require.Len(t, mdd, 1)
-			ocmd := internaldata.MetricsToOC(mdd[0])
-			require.Len(t, ocmd, 1)
-			require.Len(t, ocmd[0].Metrics, 1)
-			metric := ocmd[0].Metrics[0]
-			assert.Equal(t, "test.metric", metric.GetMetricDescriptor().GetName())
+func Test_TCPServer_MaxLineLength(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, time.Minute, 32)
+	require.NoError(t, err)
 
-			// require.Equal(t, 1, len(mc.md))
-			// assert.Equal(t, "test.metric", mc.md[0].Metrics[0].GetMetricDescriptor().GetName())
-		})
+	transferChan := make(chan string, 10)
+	go srv.ListenAndServe(transferChan, NewMockReporter(0))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "test.metric:1|c\n%s:2|c\ntest.metric:3|c\n", strings.Repeat("a", 64))
+	require.NoError(t, err)
+
+	assert.Equal(t, "test.metric:1|c", <-transferChan)
+
+	// The connection is closed after the line that is too long.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.Len(t, transferChan, 0)
+}
+
+func Test_TCPServer_IdleTimeout(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 50*time.Millisecond, 1024)
+	require.NoError(t, err)
+
+	transferChan := make(chan string, 10)
+	go srv.ListenAndServe(transferChan, NewMockReporter(0))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The idle connection is closed by the server.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	if netErr, ok := err.(net.Error); ok {
+		assert.False(t, netErr.Timeout())
 	}
 }
+
+func Test_UnixgramServer_RemovesSocket(t *testing.T) {
+	path := socketPath(t)
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_UnixgramServer_RemovesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+
+	// a socket left behind by a previous run, closing the connection doesn't remove it
+	stale, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	require.NoError(t, stale.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+	assert.NoError(t, srv.Close())
+}
+
+func Test_UnixgramServer_KeepsOtherFiles(t *testing.T) {
+	path := socketPath(t)
+	require.NoError(t, ioutil.WriteFile(path, []byte("not a socket"), 0600))
+
+	_, err := NewUnixgramServer(path)
+	assert.Error(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "not a socket", string(content))
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func socketPath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "statsd")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "statsd.sock")
+}
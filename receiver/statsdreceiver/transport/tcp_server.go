@@ -0,0 +1,163 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+type tcpServer struct {
+	listener      net.Listener
+	reporter      Reporter
+	idleTimeout   time.Duration
+	maxLineLength int
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ (Server) = (*tcpServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport, with
+// newline separated lines. Connections idle for longer than idleTimeout are
+// closed, as are connections sending lines longer than maxLineLength bytes.
+func NewTCPServer(addr string, idleTimeout time.Duration, maxLineLength int) (Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tcpServer{
+		listener:      listener,
+		idleTimeout:   idleTimeout,
+		maxLineLength: maxLineLength,
+		conns:         make(map[net.Conn]struct{}),
+	}
+	return &t, nil
+}
+
+func (t *tcpServer) ListenAndServe(
+	transferChan chan<- string,
+	reporter Reporter,
+) error {
+	if transferChan == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	t.reporter = reporter
+
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			t.reporter.OnDebugf("TCP Transport (%s) - Accept error: %v",
+				t.listener.Addr(),
+				err)
+			if netErr, ok := err.(net.Error); ok {
+				if netErr.Temporary() {
+					continue
+				}
+			}
+			return err
+		}
+
+		if !t.track(conn) {
+			conn.Close()
+			continue
+		}
+		go t.handleConn(conn, transferChan)
+	}
+}
+
+// Close stops accepting connections, closes the open ones and waits for the
+// lines already read from them to be sent to transferChan.
+func (t *tcpServer) Close() error {
+	err := t.listener.Close()
+
+	t.mu.Lock()
+	t.closed = true
+	for conn := range t.conns {
+		conn.Close()
+	}
+	t.mu.Unlock()
+
+	t.wg.Wait()
+	return err
+}
+
+// track registers the connection so that it is closed by Close. It returns
+// false if the server is already closed.
+func (t *tcpServer) track(conn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return false
+	}
+	t.conns[conn] = struct{}{}
+	t.wg.Add(1)
+	return true
+}
+
+func (t *tcpServer) untrack(conn net.Conn) {
+	t.mu.Lock()
+	delete(t.conns, conn)
+	t.mu.Unlock()
+	t.wg.Done()
+}
+
+func (t *tcpServer) handleConn(conn net.Conn, transferChan chan<- string) {
+	defer t.untrack(conn)
+	defer conn.Close()
+
+	// The maximum line length is the larger of the buffer capacity and the
+	// given maximum, so the buffer must not start larger than the maximum.
+	bufSize := 4096
+	if t.maxLineLength < bufSize {
+		bufSize = t.maxLineLength
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, bufSize), t.maxLineLength)
+	for {
+		if t.idleTimeout > 0 {
+			if err := conn.SetReadDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+				t.reporter.OnDebugf("TCP Transport (%s) - SetReadDeadline error: %v",
+					conn.RemoteAddr(),
+					err)
+				return
+			}
+		}
+
+		if !scanner.Scan() {
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- line
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.reporter.OnDebugf("TCP Transport (%s) - closing connection: %v",
+			conn.RemoteAddr(),
+			err)
+	}
+}